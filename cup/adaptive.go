@@ -0,0 +1,88 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// AdaptiveLayout places two components side by side on wide terminal, and
+// stacks them on narrow one.
+//
+// It is a [FixedLeftLayout] reserving leftWidth columns for first component
+// if width is at least MinWidth, or a [FixedTopLayout] reserving topHeight rows
+// for first component otherwise. Both components are resized when it switches.
+//
+// Stacking needs height too, so components are placed side by side on a
+// terminal shorter than MinHeight, even if it is narrower than MinWidth.
+type AdaptiveLayout struct {
+	// MinWidth is the minimal width to place components side by side. It
+	// takes effect on next resize.
+	MinWidth int
+	// MinHeight is the minimal height to stack components, 0 disables it.
+	// It takes effect on next resize.
+	MinHeight int
+
+	leftWidth, topHeight int
+	horizontal           bool
+	active               *FixedLayout
+}
+
+// NewAdaptiveLayout creates an AdaptiveLayout, see [AdaptiveLayout] for
+// details. Components are stacked before first resize.
+func NewAdaptiveLayout(minWidth, leftWidth, topHeight int, first, second tea.Model) *AdaptiveLayout {
+	return &AdaptiveLayout{
+		MinWidth:  minWidth,
+		leftWidth: leftWidth,
+		topHeight: topHeight,
+		active:    FixedTopLayout(topHeight, first, second),
+	}
+}
+
+// Horizontal reports whether components are placed side by side.
+func (a *AdaptiveLayout) Horizontal() bool {
+	return a.horizontal
+}
+
+// switchTo rebuilds underlying layout with current components
+func (a *AdaptiveLayout) switchTo(horizontal bool) {
+	first := a.active.components[0].Model
+	second := a.active.components[1].Model
+	a.horizontal = horizontal
+	if horizontal {
+		a.active = FixedLeftLayout(a.leftWidth, first, second)
+	} else {
+		a.active = FixedTopLayout(a.topHeight, first, second)
+	}
+}
+
+// wantHorizontal reports whether components should be placed side by side in
+// a width x height area
+func (a *AdaptiveLayout) wantHorizontal(width, height int) bool {
+	return width >= a.MinWidth || height < a.MinHeight
+}
+
+func (a *AdaptiveLayout) Init() tea.Cmd {
+	return a.active.Init()
+}
+
+func (a *AdaptiveLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return a.Update(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		if want := a.wantHorizontal(msg.Width, msg.Height); want != a.horizontal {
+			a.switchTo(want)
+		}
+	}
+
+	_, cmd := a.active.Update(msg)
+	return a, cmd
+}
+
+func (a *AdaptiveLayout) View() string {
+	return a.active.View()
+}
@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveLayout(t *testing.T) {
+	first, second := pearl.NewSpan(), pearl.NewSpan()
+	first.SetContent("A")
+	second.SetContent("B")
+	a := NewAdaptiveLayout(6, 2, 1, first, second)
+
+	a.Update(tapioca.ResizeMsg{Width: 5, Height: 3})
+	assert.False(t, a.Horizontal())
+	assert.Equal(t, "A    \nB    \n     ", a.View())
+
+	// wide enough
+	a.Update(tapioca.ResizeMsg{Width: 6, Height: 2})
+	assert.True(t, a.Horizontal())
+	assert.Equal(t, "A B   \n      ", a.View())
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  6,
+		Height: 2,
+		Model:  a,
+	}))
+
+	// back to narrow
+	a.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	assert.False(t, a.Horizontal())
+	assert.Equal(t, "A   \nB   ", a.View())
+
+	// messages still reach components after switching
+	setter := second.Setter(func(msg tea.Msg) { a.Update(msg) })
+	setter("C")
+	assert.Equal(t, "A   \nC   ", a.View())
+}
+
+func TestAdaptiveLayout_MinHeight(t *testing.T) {
+	first, second := pearl.NewSpan(), pearl.NewSpan()
+	first.SetContent("A")
+	second.SetContent("B")
+	a := NewAdaptiveLayout(6, 2, 1, first, second)
+	a.MinHeight = 3
+
+	// narrow but too short to stack
+	a.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	assert.True(t, a.Horizontal())
+	assert.Equal(t, "A B \n    ", a.View())
+
+	// tall enough
+	a.Update(tapioca.ResizeMsg{Width: 4, Height: 3})
+	assert.False(t, a.Horizontal())
+	assert.Equal(t, "A   \nB   \n    ", a.View())
+
+	// wide terminal is always side by side
+	a.Update(tapioca.ResizeMsg{Width: 6, Height: 5})
+	assert.True(t, a.Horizontal())
+
+	// back to short
+	a.Update(tapioca.ResizeMsg{Width: 4, Height: 3})
+	assert.False(t, a.Horizontal())
+	a.Update(tapioca.ResizeMsg{Width: 4, Height: 1})
+	assert.True(t, a.Horizontal())
+	assert.Equal(t, "A B ", a.View())
+}
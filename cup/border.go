@@ -23,6 +23,7 @@ func DefaultBorderConfig() BorderConfig {
 		TopRightCorner:    '┐',
 		BottomLeftCorner:  '└',
 		BottomRightCorner: '┘',
+		ShadowRune:        '░',
 	}
 }
 
@@ -30,10 +31,22 @@ type BorderConfig struct {
 	Left, Top, Right, Bottom bool
 	VerticalLine             rune
 	HorizontalLine           rune
-	TopLeftCorner            rune
-	TopRightCorner           rune
-	BottomLeftCorner         rune
-	BottomRightCorner        rune
+	// Corners set to 0 are drawn with HorizontalLine, or VerticalLine if
+	// HorizontalLine is 0, so "-" and "|" gives a cornerless look.
+	TopLeftCorner     rune
+	TopRightCorner    rune
+	BottomLeftCorner  rune
+	BottomRightCorner rune
+
+	// Shadow draws a drop shadow at right and bottom side, which takes 1
+	// column and 1 row from the box.
+	Shadow bool
+	// ShadowRune is used to draw the shadow in dim style, it must be 1
+	// column wide.
+	ShadowRune rune
+	// Style is ANSI SGR sequence to draw borders, like "\x1b[31m" for red
+	// borders. Caption is not affected. Empty string uses terminal default.
+	Style string
 }
 
 func (bc *BorderConfig) size() (v, h int) {
@@ -46,6 +59,18 @@ func (bc *BorderConfig) size() (v, h int) {
 	return
 }
 
+// corner returns the rune to draw a corner set to r
+func (bc *BorderConfig) corner(r rune) rune {
+	switch {
+	case r != 0:
+		return r
+	case bc.HorizontalLine != 0:
+		return bc.HorizontalLine
+	default:
+		return bc.VerticalLine
+	}
+}
+
 type BorderedBox struct {
 	id int64
 	BorderConfig
@@ -76,6 +101,12 @@ type BorderedBoxSetCaptionMsg struct {
 	caption string
 }
 
+// BorderedBoxSetStyleMsg is a message to change border style of a BorderedBox.
+type BorderedBoxSetStyleMsg struct {
+	id    int64
+	style string
+}
+
 func NewBorderedBox(inner tea.Model) *BorderedBox {
 	return NewBorderedBoxWithCaption(inner, "")
 }
@@ -93,34 +124,50 @@ func (b *BorderedBox) SetCaption(c string) {
 	b.caption = tapioca.NewEntry(c)
 }
 
+// Caption returns the caption as plain text, styles are removed.
+func (b *BorderedBox) Caption() string {
+	return b.caption.String()
+}
+
 func (b *BorderedBox) Setter(send func(tea.Msg)) func(string) {
 	return func(c string) {
 		send(BorderedBoxSetCaptionMsg{b.id, c})
 	}
 }
 
+// StyleSetter returns a function that sends a BorderedBoxSetStyleMsg to change
+// [BorderConfig.Style].
+func (b *BorderedBox) StyleSetter(send func(tea.Msg)) func(string) {
+	return func(s string) {
+		send(BorderedBoxSetStyleMsg{b.id, s})
+	}
+}
+
 func (b *BorderedBox) Init() tea.Cmd {
 	b.vLineWidth, b.hLineWidth = b.BorderConfig.size()
-	b.lt = tapioca.RuneWidth(b.TopLeftCorner)
-	b.lb = tapioca.RuneWidth(b.BottomLeftCorner)
-	b.rt = tapioca.RuneWidth(b.TopRightCorner)
-	b.rb = tapioca.RuneWidth(b.BottomRightCorner)
+	b.lt = tapioca.RuneWidth(b.corner(b.TopLeftCorner))
+	b.lb = tapioca.RuneWidth(b.corner(b.BottomLeftCorner))
+	b.rt = tapioca.RuneWidth(b.corner(b.TopRightCorner))
+	b.rb = tapioca.RuneWidth(b.corner(b.BottomRightCorner))
 	return b.inner.Init()
 }
 
 func (b *BorderedBox) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return b.Update(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
 	case BorderedBoxSetCaptionMsg:
 		if msg.id == b.id {
 			b.SetCaption(msg.caption)
 		}
+	case BorderedBoxSetStyleMsg:
+		if msg.id == b.id {
+			b.Style = msg.style
+		}
 	case tapioca.ResizeMsg:
 		b.computeSize(msg.Width, msg.Height)
-		b.inner, cmd = b.inner.Update(tapioca.ResizeMsg{
-			Width:  b.wReserve,
-			Height: b.hReserve,
-		})
+		b.inner, cmd = tapioca.Resize(b.inner, b.wReserve, b.hReserve)
 	default:
 		b.inner, cmd = b.inner.Update(msg)
 	}
@@ -129,6 +176,9 @@ func (b *BorderedBox) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (b *BorderedBox) computeSize(width, height int) {
 	b.size = width * height
+	if b.Shadow {
+		width, height = width-1, height-1
+	}
 	b.wReserve, b.hReserve = width, height
 	if b.Left {
 		b.wReserve -= b.vLineWidth
@@ -136,11 +186,12 @@ func (b *BorderedBox) computeSize(width, height int) {
 	if b.Right {
 		b.wReserve -= b.vLineWidth
 	}
+	// horizontal borders take one row, even if drawn with wide characters
 	if b.Top {
-		b.hReserve -= b.hLineWidth
+		b.hReserve--
 	}
 	if b.Bottom {
-		b.hReserve -= b.hLineWidth
+		b.hReserve--
 	}
 
 	b.hReserve = max(0, b.hReserve)
@@ -148,6 +199,9 @@ func (b *BorderedBox) computeSize(width, height int) {
 
 	// if width is odd number with wide character border, we have to leave 1 char at right
 	b.reminder = b.wReserve%2 == 1 && b.hLineWidth == 2
+	if b.reminder {
+		b.wReserve--
+	}
 
 	b.hasError = b.wReserve < 2 || b.hReserve < 1
 }
@@ -168,11 +222,11 @@ func (b *BorderedBox) View() string {
 	innerView := strings.Split(strings.TrimRight(b.inner.View(), "\n"), "\n")
 	for i := 0; i < b.hReserve; i++ {
 		if b.Left {
-			buf.WriteRune(b.VerticalLine)
+			b.writeBorder(buf, string(b.VerticalLine))
 		}
 		buf.WriteString(innerView[i])
 		if b.Right {
-			buf.WriteRune(b.VerticalLine)
+			b.writeBorder(buf, string(b.VerticalLine))
 		}
 		if b.reminder {
 			buf.WriteRune(' ')
@@ -183,21 +237,53 @@ func (b *BorderedBox) View() string {
 	if b.Bottom {
 		// render bottom line
 		w := b.wReserve
+		b.beginStyle(buf)
 		if b.Left {
-			buf.WriteRune(b.BottomLeftCorner)
+			buf.WriteRune(b.corner(b.BottomLeftCorner))
 		}
 		for w >= b.hLineWidth {
 			buf.WriteRune(b.HorizontalLine)
 			w -= b.hLineWidth
 		}
 		if b.Right {
-			buf.WriteRune(b.BottomRightCorner)
+			buf.WriteRune(b.corner(b.BottomRightCorner))
 		}
+		b.endStyle(buf)
 		if b.reminder {
 			buf.WriteRune(' ')
 		}
 	}
 
+	if b.Shadow {
+		return b.withShadow(buf.String())
+	}
+	return buf.String()
+}
+
+// withShadow appends shadow to the rendered box
+func (b *BorderedBox) withShadow(box string) string {
+	lines := strings.Split(strings.TrimRight(box, "\n"), "\n")
+	shadow := string(b.ShadowRune)
+	if b.ShadowRune == 0 {
+		shadow = " "
+	}
+	dim := func(s string) string { return "\x1b[2m" + s + "\x1b[0m" }
+
+	buf := &strings.Builder{}
+	buf.Grow(len(box) + b.size/2) // shadow and ANSI codes
+	for i, l := range lines {
+		buf.WriteString(l)
+		if i == 0 {
+			buf.WriteByte(' ')
+		} else {
+			buf.WriteString(dim(shadow))
+		}
+		buf.WriteByte('\n')
+	}
+
+	w := tapioca.NewEntry(lines[0]).Width()
+	buf.WriteByte(' ')
+	buf.WriteString(dim(strings.Repeat(shadow, w)))
 	return buf.String()
 }
 
@@ -223,13 +309,15 @@ func (b *BorderedBox) renderCaption(buf *strings.Builder) int {
 	have := min(captionWidth, wreserved)
 	wreserved -= have
 
-	if captionWidth > have {
-		buf.WriteString(b.caption.StyledMove(0, have-2))
-		buf.WriteString("…")
-	} else {
-		buf.WriteString(b.caption.StyledMove(0, have))
-	}
+	b.endStyle(buf)
+	buf.WriteString(b.caption.StyledTruncate(have, '…'))
+	b.beginStyle(buf)
 
+	// rest of the line must be filled with whole horizontal lines
+	if pad := wreserved % max(1, b.hLineWidth); pad > 0 {
+		buf.WriteString(strings.Repeat(" ", pad))
+		wreserved -= pad
+	}
 	buf.WriteRune(' ')
 	buf.WriteRune(b.HorizontalLine)
 
@@ -237,8 +325,9 @@ func (b *BorderedBox) renderCaption(buf *strings.Builder) int {
 }
 
 func (b *BorderedBox) renderTop(buf *strings.Builder) {
+	b.beginStyle(buf)
 	if b.Left {
-		buf.WriteRune(b.TopLeftCorner)
+		buf.WriteRune(b.corner(b.TopLeftCorner))
 	}
 
 	w := b.renderCaption(buf)
@@ -247,10 +336,27 @@ func (b *BorderedBox) renderTop(buf *strings.Builder) {
 		w -= b.hLineWidth
 	}
 	if b.Right {
-		buf.WriteRune(b.TopRightCorner)
+		buf.WriteRune(b.corner(b.TopRightCorner))
 	}
+	b.endStyle(buf)
 	if b.reminder {
 		buf.WriteRune(' ')
 	}
 	buf.WriteRune('\n')
 }
+
+func (b *BorderedBox) beginStyle(buf *strings.Builder) {
+	buf.WriteString(b.Style)
+}
+
+func (b *BorderedBox) endStyle(buf *strings.Builder) {
+	if b.Style != "" {
+		buf.WriteString("\x1b[0m")
+	}
+}
+
+func (b *BorderedBox) writeBorder(buf *strings.Builder, s string) {
+	b.beginStyle(buf)
+	buf.WriteString(s)
+	b.endStyle(buf)
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/raohwork/huninn/pearl"
 	"github.com/raohwork/huninn/tapioca"
 	"github.com/stretchr/testify/assert"
@@ -369,7 +371,7 @@ func TestBorderedBox_RenderCaption(t *testing.T) {
 		{
 			name:              "caption exceeds width",
 			caption:           "abcdefgh",
-			expectedString:    "─ ab… ─",
+			expectedString:    "─ abc… ─",
 			expectedRestWidth: 0,
 		},
 	}
@@ -399,6 +401,66 @@ func TestBorderedBox_RenderCaption(t *testing.T) {
 	}
 }
 
+func TestBorderedBox_CaptionTinyWidths(t *testing.T) {
+	borders := map[string]BorderConfig{
+		"single": DefaultBorderConfig(),
+		"double": {
+			Left: true, Top: true, Right: true, Bottom: true,
+			VerticalLine:      '｜',
+			HorizontalLine:    '＝',
+			TopLeftCorner:     '＋',
+			TopRightCorner:    '＋',
+			BottomLeftCorner:  '＋',
+			BottomRightCorner: '＋',
+		},
+	}
+	captions := []string{"", "a", "ab", "abc", "abcdef", "你", "你好嗎", "\x1b[1mbold\x1b[0m"}
+
+	for name, bc := range borders {
+		for width := 5; width <= 12; width++ {
+			for _, caption := range captions {
+				box := NewBorderedBoxWithCaption(pearl.NewBlock(), caption)
+				box.BorderConfig = bc
+				box.Init()
+				box.Update(tapioca.ResizeMsg{Width: width, Height: 5})
+				if box.hasError {
+					continue
+				}
+
+				top, _, _ := strings.Cut(box.View(), "\n")
+				assert.Equal(t, width, tapioca.NewEntry(top).Width(), "%s border, width %d, caption %q: %q", name, width, caption, top)
+				assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{Width: width, Height: 5, Model: box}), "%s border, width %d, caption %q", name, width, caption)
+			}
+		}
+	}
+
+	t.Run("wide line", func(t *testing.T) {
+		box := NewBorderedBoxWithCaption(pearl.NewBlock(), "a")
+		box.BorderConfig = borders["double"]
+		box.Init()
+
+		// odd caption leaves a column before the line
+		box.Update(tapioca.ResizeMsg{Width: 12, Height: 5})
+		top, _, _ := strings.Cut(box.View(), "\n")
+		assert.Equal(t, "＋＝ a  ＝＋", top)
+
+		// and the reminder column at right
+		box.Update(tapioca.ResizeMsg{Width: 13, Height: 5})
+		top, _, _ = strings.Cut(box.View(), "\n")
+		assert.Equal(t, "＋＝ a  ＝＋ ", top)
+	})
+
+	t.Run("wide border, odd width", func(t *testing.T) {
+		box := NewBorderedBox(pearl.NewBlock())
+		box.BorderConfig = borders["double"]
+		box.Init()
+
+		// inner component gets even width, reminder column on every row
+		box.Update(tapioca.ResizeMsg{Width: 7, Height: 3})
+		assert.Equal(t, "＋＝＋ \n｜  ｜ \n＋＝＋ ", box.View())
+	})
+}
+
 func TestBorderedBox_CaptionCases(t *testing.T) {
 	f := func(expected []string) string {
 		return strings.Join(expected, "\n")
@@ -437,3 +499,113 @@ func TestBorderedBox_CaptionCases(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestBorderedBox_WindowSizeMsg(t *testing.T) {
+	span := pearl.NewSpan()
+	span.SetContent("hi")
+	box := NewBorderedBox(span)
+	box.Init()
+	box.Update(tea.WindowSizeMsg{Width: 6, Height: 3})
+	assert.Equal(t, "┌────┐\n│hi  │\n└────┘", box.View())
+}
+
+func TestBorderedBox_Caption(t *testing.T) {
+	box := NewBorderedBoxWithCaption(pearl.NewSpan(), "\x1b[1mtitle\x1b[0m")
+	assert.Equal(t, "title", box.Caption())
+
+	setter := box.Setter(func(msg tea.Msg) { box.Update(msg) })
+	setter("new")
+	assert.Equal(t, "new", box.Caption())
+}
+
+func TestBorderedBox_Style(t *testing.T) {
+	span := pearl.NewSpan()
+	span.SetContent("hi")
+	box := NewBorderedBoxWithCaption(span, "c")
+	box.Init()
+	box.Update(tapioca.ResizeMsg{Width: 8, Height: 3})
+
+	setter := box.StyleSetter(func(msg tea.Msg) { box.Update(msg) })
+	setter("\x1b[31m")
+	red := func(s string) string { return "\x1b[31m" + s + "\x1b[0m" }
+	assert.Equal(t, red("┌─ ")+"c"+red(" ──┐")+"\n"+
+		red("│")+"hi    "+red("│")+"\n"+
+		red("└──────┘"), box.View())
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  8,
+		Height: 3,
+		Model:  box,
+	}))
+
+	setter("")
+	assert.Equal(t, "┌─ c ──┐\n│hi    │\n└──────┘", box.View())
+}
+
+func TestBorderedBox_Shadow(t *testing.T) {
+	span := pearl.NewSpan()
+	span.SetContent("hi")
+	box := NewBorderedBox(span)
+	box.Shadow = true
+	box.ShadowRune = '#'
+	box.Init()
+	box.Update(tapioca.ResizeMsg{Width: 7, Height: 4})
+
+	dim := "\x1b[2m#\x1b[0m"
+	assert.Equal(t, ""+
+		"┌────┐ \n"+
+		"│hi  │"+dim+"\n"+
+		"└────┘"+dim+"\n"+
+		" \x1b[2m######\x1b[0m",
+		box.View())
+
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  7,
+		Height: 4,
+		Model:  box,
+	}))
+}
+
+func TestBorderedBox_Cornerless(t *testing.T) {
+	render := func(bc BorderConfig) string {
+		span := pearl.NewSpan()
+		span.SetContent("hi")
+		box := NewBorderedBoxWithCaption(span, "c")
+		box.BorderConfig = bc
+		box.Init()
+		box.Update(tapioca.ResizeMsg{Width: 8, Height: 3})
+		assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{Width: 8, Height: 3, Model: box}))
+		return box.View()
+	}
+
+	t.Run("ascii", func(t *testing.T) {
+		bc := BorderConfig{
+			Left: true, Top: true, Right: true, Bottom: true,
+			VerticalLine:   '|',
+			HorizontalLine: '-',
+		}
+		assert.Equal(t, "-- c ---\n|hi    |\n--------", render(bc))
+	})
+
+	t.Run("some corners", func(t *testing.T) {
+		bc := BorderConfig{
+			Left: true, Top: true, Right: true, Bottom: true,
+			VerticalLine:      '|',
+			HorizontalLine:    '-',
+			TopLeftCorner:     '+',
+			BottomRightCorner: '+',
+		}
+		assert.Equal(t, "+- c ---\n|hi    |\n-------+", render(bc))
+	})
+
+	t.Run("no horizontal line", func(t *testing.T) {
+		bc := BorderConfig{
+			Left: true, Right: true, Bottom: true,
+			VerticalLine: '|',
+		}
+		box := NewBorderedBox(pearl.NewBlock())
+		box.BorderConfig = bc
+		box.Init()
+		assert.Equal(t, 1, box.lb)
+		assert.Equal(t, 1, box.rb)
+	})
+}
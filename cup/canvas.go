@@ -0,0 +1,143 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// Canvas lays out its child on a virtual canvas of fixed size, and shows the
+// part of it under the viewport. It lets you design a wide dashboard at, say,
+// 200 columns, and pan around it in a narrow terminal.
+//
+// The child is resized to the virtual size, or the real size if it is larger,
+// so the canvas is never smaller than the viewport. A virtual width or height
+// less than 1 follows the real size, which disables panning in that
+// direction.
+//
+// The viewport is moved by the embedded [tapioca.Scrollable], or by the
+// function returned by [Canvas.Panner]. Scroll messages like
+// [tapioca.ScrollUpMsg] are passed to the child instead, so scrollable
+// components in the dashboard work as usual.
+//
+// You must create Canvas with NewCanvas().
+type Canvas struct {
+	tapioca.Scrollable
+
+	id     int64
+	child  tea.Model
+	vw, vh int // virtual size
+	cw, ch int // size of the child
+}
+
+// CanvasPanMsg is a message to move the viewport of a Canvas.
+type CanvasPanMsg struct {
+	id         int64
+	cols, rows int
+}
+
+// NewCanvas creates a Canvas showing child at virtual size width x height.
+func NewCanvas(child tea.Model, width, height int) *Canvas {
+	ret := &Canvas{
+		id:    tapioca.NewID(),
+		child: child,
+		vw:    width,
+		vh:    height,
+	}
+	ret.Scrollable = tapioca.NewScrollable(
+		func() int { return ret.cw },
+		func() int { return ret.ch },
+	)
+	return ret
+}
+
+// ScrollController returns the scroll controller of the viewport.
+func (c *Canvas) ScrollController() tapioca.ScrollController {
+	return c
+}
+
+// VirtualSize returns the virtual size set by NewCanvas or SetVirtualSize.
+func (c *Canvas) VirtualSize() (width, height int) { return c.vw, c.vh }
+
+// SetVirtualSize changes the virtual size, and resizes the child if it has been
+// resized. The returned command comes from the child.
+//
+// You should use it only when you are handling an event message.
+func (c *Canvas) SetVirtualSize(width, height int) tea.Cmd {
+	c.vw, c.vh = width, height
+	if c.Width() <= 0 || c.Height() <= 0 {
+		return nil
+	}
+	return c.resize(c.Width(), c.Height())
+}
+
+// Panner returns a function that sends a CanvasPanMsg to move the viewport by
+// cols and rows, negative values move left/up.
+func (c *Canvas) Panner(send func(tea.Msg)) func(cols, rows int) {
+	return func(cols, rows int) {
+		send(CanvasPanMsg{id: c.id, cols: cols, rows: rows})
+	}
+}
+
+// resize lays out the child for a w x h viewport
+func (c *Canvas) resize(w, h int) tea.Cmd {
+	c.cw, c.ch = w, h
+	if c.vw > 0 {
+		c.cw = max(c.vw, w)
+	}
+	if c.vh > 0 {
+		c.ch = max(c.vh, h)
+	}
+
+	var cmd tea.Cmd
+	c.child, cmd = tapioca.Resize(c.child, c.cw, c.ch)
+	c.HandleEvent(tapioca.ResizeMsg{Width: w, Height: h})
+	return cmd
+}
+
+func (c *Canvas) Init() tea.Cmd { return c.child.Init() }
+
+func (c *Canvas) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return c.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *Canvas instead of tea.Model.
+func (c *Canvas) UpdateInto(msg tea.Msg) (*Canvas, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return c.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		cmd = c.resize(msg.Width, msg.Height)
+	case CanvasPanMsg:
+		if msg.id == c.id {
+			c.ScrollBy(msg.cols, msg.rows)
+		}
+	default:
+		c.child, cmd = c.child.Update(msg)
+	}
+	return c, cmd
+}
+
+func (c *Canvas) View() string {
+	w, h := c.Width(), c.Height()
+	if w <= 0 || h <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(c.child.View(), "\n")
+	ret := make([]string, h)
+	for i := range ret {
+		if y := c.Y() + i; y < len(lines) {
+			ret[i] = tapioca.NewEntry(lines[y]).StyledWindow(c.X(), w)
+			continue
+		}
+		ret[i] = tapioca.BlankLine(w)
+	}
+	return strings.Join(ret, "\n")
+}
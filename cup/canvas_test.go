@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanvas(t *testing.T) {
+	for _, size := range [][2]int{{1, 1}, {5, 3}, {20, 5}, {30, 8}} {
+		b := pearl.NewBlock()
+		b.SetContent("0123456789abcdefghij", "line 2")
+		assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  size[0],
+			Height: size[1],
+			Model:  NewCanvas(b, 20, 5),
+		}), "%dx%d", size[0], size[1])
+	}
+}
+
+func TestCanvas_View(t *testing.T) {
+	b := pearl.NewBlock()
+	b.SetContent("0123456789", "abcdefghij", "ABCDEFGHIJ", "中文字元測試")
+	c := NewCanvas(b, 12, 4)
+	c.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	assert.Equal(t, "0123\nabcd", c.View())
+	assert.Equal(t, 12, c.ExtentH())
+	assert.Equal(t, 4, c.ExtentV())
+
+	var msgs []tea.Msg
+	pan := c.Panner(func(msg tea.Msg) { msgs = append(msgs, msg) })
+	pan(3, 1)
+	c.Update(msgs[0])
+	assert.Equal(t, "defg\nDEFG", c.View())
+
+	c.Update(CanvasPanMsg{id: c.id + 1, cols: -3})
+	assert.Equal(t, 3, c.X(), "should ignore message of others")
+
+	// wide rune cut at both edges
+	c.ScrollTo(3, 2)
+	assert.Equal(t, "DEFG\n 字 ", c.View())
+
+	// clamped to the canvas
+	c.ScrollTo(100, 100)
+	assert.Equal(t, 8, c.X())
+	assert.Equal(t, 2, c.Y())
+	assert.Equal(t, "IJ  \n測試", c.View())
+}
+
+func TestCanvas_Resize(t *testing.T) {
+	b := pearl.NewBlock()
+	b.SetContent("0123456789")
+	c := NewCanvas(b, 10, 0)
+
+	c.Update(tea.WindowSizeMsg{Width: 4, Height: 2})
+	assert.Equal(t, 10, b.Width(), "child should have virtual width")
+	assert.Equal(t, 2, b.Height(), "child should follow real height")
+	c.ScrollToEnd()
+	c.ScrollToBottom()
+	assert.Equal(t, 6, c.X())
+	assert.Equal(t, 0, c.Y())
+
+	// real size is larger than virtual size
+	c.Update(tapioca.ResizeMsg{Width: 12, Height: 3})
+	assert.Equal(t, 12, b.Width())
+	assert.Equal(t, 0, c.X(), "should be clamped")
+	assert.Equal(t, "0123456789  \n            \n            ", c.View())
+
+	c.SetVirtualSize(20, 5)
+	assert.Equal(t, 20, b.Width())
+	assert.Equal(t, 5, b.Height())
+	w, h := c.VirtualSize()
+	assert.Equal(t, 20, w)
+	assert.Equal(t, 5, h)
+}
+
+func TestCanvas_ScrollMsgToChild(t *testing.T) {
+	b := pearl.NewBlock()
+	b.SetContent("0123456789")
+	c := NewCanvas(b, 10, 1)
+	c.Update(tapioca.ResizeMsg{Width: 4, Height: 1})
+	c.Update(tapioca.ScrollRightMsg(2))
+	assert.Equal(t, 0, c.X(), "canvas should not scroll")
+	assert.Equal(t, "0123", c.View())
+}
@@ -0,0 +1,146 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// Collapsible is a container which can collapse to a single caption line, or
+// expand to show its child in a [BorderedBox].
+//
+// The child is resized only when expanded. When it is expanded again, the
+// child receives a ResizeMsg with the size of the box.
+//
+// Collapsible always renders in the size it is given. Parent layouts can use
+// [Collapsible.PreferredHeight] to shrink it when collapsed.
+//
+// You must create Collapsible with NewCollapsible().
+type Collapsible struct {
+	id        int64
+	box       *BorderedBox
+	collapsed bool
+	w, h      int
+}
+
+// CollapsibleToggleMsg is a message to toggle a Collapsible.
+type CollapsibleToggleMsg struct {
+	id int64
+}
+
+// NewCollapsible creates an expanded Collapsible with caption and child.
+func NewCollapsible(caption string, child tea.Model) *Collapsible {
+	return &Collapsible{
+		id:  tapioca.NewID(),
+		box: NewBorderedBoxWithCaption(child, caption),
+	}
+}
+
+// Box returns the underlying BorderedBox, which is used to configure borders
+// and caption.
+func (c *Collapsible) Box() *BorderedBox { return c.box }
+
+// Collapsed reports whether only the caption line is shown.
+func (c *Collapsible) Collapsed() bool { return c.collapsed }
+
+// PreferredHeight returns 1 when collapsed, or the height it was resized to
+// when expanded.
+func (c *Collapsible) PreferredHeight() int {
+	if c.collapsed {
+		return 1
+	}
+	return c.h
+}
+
+// Toggle collapses or expands the container. The returned command comes from
+// the child when it is resized.
+//
+// You should use it only when you are handling an event message.
+func (c *Collapsible) Toggle() tea.Cmd {
+	return c.SetCollapsed(!c.collapsed)
+}
+
+// SetCollapsed collapses or expands the container, see [Collapsible.Toggle].
+func (c *Collapsible) SetCollapsed(collapsed bool) tea.Cmd {
+	if c.collapsed == collapsed {
+		return nil
+	}
+	c.collapsed = collapsed
+	if collapsed || c.w <= 0 {
+		return nil
+	}
+
+	_, cmd := c.box.Update(tapioca.ResizeMsg{Width: c.w, Height: c.h})
+	return cmd
+}
+
+// Toggler returns a function that sends a CollapsibleToggleMsg.
+func (c *Collapsible) Toggler(send func(tea.Msg)) func() {
+	return func() {
+		send(CollapsibleToggleMsg{id: c.id})
+	}
+}
+
+func (c *Collapsible) Init() tea.Cmd {
+	return c.box.Init()
+}
+
+func (c *Collapsible) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return c.Update(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		c.w, c.h = msg.Width, msg.Height
+		if !c.collapsed {
+			_, cmd = c.box.Update(msg)
+		}
+	case CollapsibleToggleMsg:
+		if msg.id == c.id {
+			cmd = c.Toggle()
+		}
+	default:
+		_, cmd = c.box.Update(msg)
+	}
+	return c, cmd
+}
+
+func (c *Collapsible) View() string {
+	if !c.collapsed {
+		return c.box.View()
+	}
+	if c.w <= 0 || c.h <= 0 {
+		return ""
+	}
+
+	lines := make([]string, c.h)
+	lines[0] = c.captionLine()
+	for i := 1; i < c.h; i++ {
+		lines[i] = tapioca.BlankLine(c.w)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// captionLine renders the caption like top border of the box
+//
+//	"─ caption ────"
+func (c *Collapsible) captionLine() string {
+	hline := string(c.box.HorizontalLine)
+	hw := tapioca.DisplayWidth(hline)
+	if hw <= 0 {
+		hline, hw = " ", 1
+	}
+
+	buf := &strings.Builder{}
+	buf.WriteString(hline)
+	if caption := c.box.caption.StyledString(); caption != "" {
+		buf.WriteString(" " + caption + " ")
+	}
+	buf.WriteString(strings.Repeat(hline, c.w/hw+1))
+	return tapioca.NewEntry(buf.String()).StyledMove(0, c.w)
+}
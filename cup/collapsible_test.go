@@ -0,0 +1,56 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollapsible(t *testing.T) {
+	span := pearl.NewSpan()
+	span.SetContent("hello")
+	c := NewCollapsible("cap", span)
+	c.Init()
+	c.Update(tapioca.ResizeMsg{Width: 10, Height: 3})
+
+	assert.False(t, c.Collapsed())
+	assert.Equal(t, 3, c.PreferredHeight())
+	assert.Equal(t, "┌─ cap ──┐\n│hello   │\n└────────┘", c.View())
+
+	c.Toggle()
+	assert.True(t, c.Collapsed())
+	assert.Equal(t, 1, c.PreferredHeight())
+	assert.Equal(t, "─ cap ────\n          \n          ", c.View())
+
+	c.Update(tapioca.ResizeMsg{Width: 10, Height: 1})
+	assert.Equal(t, "─ cap ────", c.View())
+	assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{Width: 10, Height: 1, Model: c}))
+}
+
+func TestCollapsible_ResizeOnToggle(t *testing.T) {
+	inner := &MockRenderComponent{}
+	inner.On("Init").Return(nil)
+	inner.On("Update", tapioca.ResizeMsg{Width: 8, Height: 3}).Return(inner, nil).Once()
+	inner.On("Update", tapioca.ResizeMsg{Width: 6, Height: 2}).Return(inner, nil).Once()
+
+	c := NewCollapsible("cap", inner)
+	c.Init()
+	c.Update(tapioca.ResizeMsg{Width: 10, Height: 5})
+
+	// child is not resized while collapsed
+	c.Toggle()
+	c.Update(tapioca.ResizeMsg{Width: 8, Height: 4})
+	inner.AssertNumberOfCalls(t, "Update", 1)
+
+	send := func(msg tea.Msg) { c.Update(msg) }
+	c.Toggler(send)()
+	assert.False(t, c.Collapsed())
+	inner.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@
 package cup
 
 import (
+	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -24,6 +25,9 @@ type FixedLayout struct {
 
 	horizontal bool
 	end        bool
+
+	tracing bool
+	trace   *ResizeTrace
 }
 
 // FixedLeftLayout reserves space on the left side of the layout.
@@ -60,10 +64,11 @@ func (e *emptyLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 func (e *emptyLayout) View() string {
 	b := strings.Builder{}
-	b.WriteString(strings.Repeat(" ", e.w))
+	line := tapioca.BlankLine(e.w)
+	b.WriteString(line)
 	for i := 1; i < e.h; i++ {
 		b.Write([]byte{'\n'})
-		b.WriteString(strings.Repeat(" ", e.w))
+		b.WriteString(line)
 	}
 	return b.String()
 }
@@ -122,8 +127,10 @@ func (f *FixedLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		cmds = append(cmds, f.handleResize(msg.Width, msg.Height)...)
+		f.recordTrace(msg.Width, msg.Height)
 	case tapioca.ResizeMsg:
 		cmds = append(cmds, f.handleResize(msg.Width, msg.Height)...)
+		f.recordTrace(msg.Width, msg.Height)
 	default:
 		for i := range f.components {
 			m, cmd := f.components[i].Update(msg)
@@ -145,11 +152,11 @@ func (f *FixedLayout) handleResize(w, h int) (ret []tea.Cmd) {
 		newSize = w
 	}
 
-	newMsg := func(s int) tapioca.ResizeMsg {
+	resize := func(m tea.Model, s int) (tea.Model, tea.Cmd) {
 		if f.horizontal {
-			return tapioca.ResizeMsg{Width: s, Height: h}
+			return tapioca.Resize(m, s, h)
 		}
-		return tapioca.ResizeMsg{Width: w, Height: s}
+		return tapioca.Resize(m, w, s)
 	}
 
 	reserveAt := 0
@@ -158,7 +165,7 @@ func (f *FixedLayout) handleResize(w, h int) (ret []tea.Cmd) {
 	}
 	if f.components[reserveAt].size != f.reserve {
 		f.components[reserveAt].size = f.reserve
-		m, cmd := f.components[reserveAt].Update(newMsg(f.reserve))
+		m, cmd := resize(f.components[reserveAt].Model, f.reserve)
 		if cmd != nil {
 			ret = append(ret, cmd)
 		}
@@ -169,7 +176,7 @@ func (f *FixedLayout) handleResize(w, h int) (ret []tea.Cmd) {
 
 	f.components[1-reserveAt].size = rest
 	if rest > 0 {
-		m, cmd := f.components[1-reserveAt].Update(newMsg(rest))
+		m, cmd := resize(f.components[1-reserveAt].Model, rest)
 		if cmd != nil {
 			ret = append(ret, cmd)
 		}
@@ -179,6 +186,58 @@ func (f *FixedLayout) handleResize(w, h int) (ret []tea.Cmd) {
 	return ret
 }
 
+// TraceResize enables or disables recording resize trace, see [LastResize].
+func (f *FixedLayout) TraceResize(enable bool) {
+	f.tracing = enable
+	if !enable {
+		f.trace = nil
+	}
+}
+
+// LastResize returns how the space is distributed to components in last resize,
+// including traces of nested layouts which have tracing enabled.
+//
+// It returns nil if tracing is disabled or the layout is not resized yet.
+func (f *FixedLayout) LastResize() *ResizeTrace {
+	return f.trace
+}
+
+func (f *FixedLayout) recordTrace(w, h int) {
+	if !f.tracing {
+		return
+	}
+
+	side := "top"
+	switch {
+	case f.horizontal && f.end:
+		side = "right"
+	case f.horizontal:
+		side = "left"
+	case f.end:
+		side = "bottom"
+	}
+	f.trace = &ResizeTrace{
+		Layout:   fmt.Sprintf("fixed %s %d", side, f.reserve),
+		Width:    w,
+		Height:   h,
+		TooSmall: f.components[0].size == 0 || f.components[1].size == 0,
+	}
+	if f.trace.TooSmall {
+		return
+	}
+
+	offset := 0
+	for _, c := range f.components {
+		rect := ChildRect{Width: w, Height: c.size, Y: offset}
+		if f.horizontal {
+			rect = ChildRect{Width: c.size, Height: h, X: offset}
+		}
+		rect.Trace = childTrace(c.Model)
+		f.trace.Children = append(f.trace.Children, rect)
+		offset += c.size
+	}
+}
+
 func (f *FixedLayout) View() string {
 	if f.components[0].size == 0 || f.components[1].size == 0 {
 		return "Terminal too small"
@@ -191,6 +250,9 @@ func (f *FixedLayout) View() string {
 	return strings.TrimRight(f.components[0].Model.View(), "\n") + "\n" + f.components[1].Model.View()
 }
 
+// renderHorizontal joins left and right views side by side, one row per line.
+// The shorter view is padded with [tapioca.FillRune], measured in display
+// width of its first line.
 func renderHorizontal(left, right string) string {
 	left = strings.TrimRight(left, "\n")
 	right = strings.TrimRight(right, "\n")
@@ -199,18 +261,21 @@ func renderHorizontal(left, right string) string {
 	rightLines := strings.Split(right, "\n")
 
 	var b strings.Builder
-	b.Grow(bytes)
+	b.Grow(bytes + max(len(leftLines), len(rightLines)))
 
 	for i, l, r := 0, len(leftLines), len(rightLines); i < max(l, r); i++ {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
 		if i < l {
 			b.WriteString(leftLines[i])
 		} else {
-			b.WriteString(strings.Repeat(" ", len(leftLines[0])))
+			b.WriteString(tapioca.BlankLine(tapioca.NewEntry(leftLines[0]).Width()))
 		}
 		if i < r {
 			b.WriteString(rightLines[i])
 		} else {
-			b.WriteString(strings.Repeat(" ", len(rightLines[0])))
+			b.WriteString(tapioca.BlankLine(tapioca.NewEntry(rightLines[0]).Width()))
 		}
 	}
 	return b.String()
@@ -13,16 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func splitFixedRows(output string, width, height int, horizontal bool) []string {
-	if horizontal {
-		rows := make([]string, 0, height)
-		for start := 0; start < len(output); start += width {
-			end := min(start+width, len(output))
-			rows = append(rows, output[start:end])
-		}
-		return rows
-	}
-
+func splitFixedRows(output string, height int) []string {
 	rows := strings.Split(output, "\n")
 	if len(rows) > height && rows[len(rows)-1] == "" {
 		rows = rows[:len(rows)-1]
@@ -147,7 +138,7 @@ func TestFixedLayout_View_NormalScenarios(t *testing.T) {
 			layout := tt.makeLayout(reserveMock, restMock)
 			layout.handleResize(termWidth, termHeight)
 
-			rows := splitFixedRows(layout.View(), termWidth, termHeight, tt.horizontal)
+			rows := splitFixedRows(layout.View(), termHeight)
 			assert.Equal(t, termHeight, len(rows), "should produce %d rows", termHeight)
 			assert.Equal(t, tt.expectedRows, rows)
 		})
@@ -217,3 +208,28 @@ func TestFixedLayout_View_TerminalTooSmall(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderHorizontal(t *testing.T) {
+	cases := []struct {
+		name, left, right, expect string
+	}{
+		{"rows", "ab\ncd", "1\n2", "ab1\ncd2"},
+		{"trailing newline", "ab\ncd\n", "1\n2\n", "ab1\ncd2"},
+		{"left shorter", "ab", "1\n2", "ab1\n  2"},
+		{"right shorter", "a\nb", "12", "a12\nb  "},
+		{"wide padding", "你好", "1\n2", "你好1\n    2"},
+		{"styled padding", "\x1b[31mab\x1b[0m\ncd", "1", "\x1b[31mab\x1b[0m1\ncd "},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expect, renderHorizontal(c.left, c.right))
+		})
+	}
+
+	t.Run("fill rune", func(t *testing.T) {
+		defer func(r rune) { tapioca.FillRune = r }(tapioca.FillRune)
+		tapioca.FillRune = '.'
+		assert.Equal(t, "ab1\n..2", renderHorizontal("ab", "1\n2"))
+	})
+}
@@ -14,6 +14,7 @@ import (
 
 type gridSpec struct {
 	x, y, w, h int
+	minW, minH int
 	comp       tea.Model
 }
 
@@ -55,7 +56,48 @@ type GridLayout struct {
 	components []gridSpec
 	w, h       int
 	hasError   bool
+	err        *LayoutError
 	*gridMap
+
+	tracing   bool
+	trace     *ResizeTrace
+	remainder RemainderPolicy
+}
+
+// RemainderPolicy controls which cells get the extra columns (or rows) when the
+// size cannot be divided evenly by the number of cells.
+type RemainderPolicy int
+
+const (
+	// LeadingCells gives the remainder to leftmost (topmost) cells. It is the
+	// default policy.
+	LeadingCells RemainderPolicy = iota
+	// CenterCells gives the remainder to cells in the middle.
+	CenterCells
+	// TrailingCells gives the remainder to rightmost (bottommost) cells.
+	TrailingCells
+)
+
+// distribute divides total into n parts, each part differs at most 1. Where the
+// larger parts are placed is decided by policy.
+func distribute(total, n int, policy RemainderPolicy) []int {
+	base, rem := total/n, total%n
+	start := 0
+	switch policy {
+	case CenterCells:
+		start = (n - rem) / 2
+	case TrailingCells:
+		start = n - rem
+	}
+
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = base
+		if i >= start && i < start+rem {
+			ret[i]++
+		}
+	}
+	return ret
 }
 
 // NewGridLayout creates a new GridLayout with the specified number of columns (w)
@@ -83,7 +125,17 @@ func newGridMap(w, h int) *gridMap {
 	return &gridMap{grid: grid}
 }
 
+// Add places comp at cell (x, y), spanning w columns and h rows. It returns false
+// if the area overlaps other components.
+//
+// Pass -1 as w or h to span to the right or bottom edge of the grid.
 func (g *GridLayout) Add(comp tea.Model, x, y, w, h int) bool {
+	if w == -1 {
+		w = g.w - x
+	}
+	if h == -1 {
+		h = g.h - y
+	}
 	if !g.gridMap.add(x, y, w, h, len(g.components)) {
 		return false
 	}
@@ -92,6 +144,26 @@ func (g *GridLayout) Add(comp tea.Model, x, y, w, h int) bool {
 	return true
 }
 
+// SetRemainderPolicy sets where the extra columns and rows go when the size of
+// the layout is not divisible by number of cells. It takes effect on next
+// resize.
+func (g *GridLayout) SetRemainderPolicy(p RemainderPolicy) {
+	g.remainder = p
+}
+
+// AddBordered wraps comp in a [BorderedBox] with caption, and adds the box to the
+// grid like Add.
+//
+// It returns the box for further configuration, like changing the caption with
+// [BorderedBox.Setter]. If the box cannot be added, it returns nil and false.
+func (g *GridLayout) AddBordered(comp tea.Model, caption string, x, y, w, h int) (*BorderedBox, bool) {
+	box := NewBorderedBoxWithCaption(comp, caption)
+	if !g.Add(box, x, y, w, h) {
+		return nil, false
+	}
+	return box, true
+}
+
 func (g *GridLayout) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	for _, c := range g.components {
@@ -112,10 +184,12 @@ func (g *GridLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if c := g.handleResize(msg.Width, msg.Height); len(c) > 0 {
 			cmds = append(cmds, c...)
 		}
+		g.recordTrace(msg.Width, msg.Height)
 	case tapioca.ResizeMsg:
 		if c := g.handleResize(msg.Width, msg.Height); len(c) > 0 {
 			cmds = append(cmds, c...)
 		}
+		g.recordTrace(msg.Width, msg.Height)
 	default:
 		for i, c := range g.components {
 			newComp, cmd := c.comp.Update(msg)
@@ -138,60 +212,46 @@ func (g *GridLayout) handleResize(w, h int) []tea.Cmd {
 	//  - g.w or g.h is zero: it will be checked at NewGridLayout, ignore here
 	//  - w or h is zero: create a cache field in GridLayout to indicate
 	//    that something is wrong, cannot render normally.
-	//  - remainder is distributed to columns/rows one by one from left to right,
-	//    top to bottom.
+	//  - remainder is distributed to columns/rows according to remainder
+	//    policy, see [distribute].
+	//  - component smaller than its minimum size: see [LayoutError].
 
 	// handle zero terminal size
 	if w <= 2 || h < 1 {
 		g.hasError = true
+		g.err = &LayoutError{Index: -1, Width: w, Height: h, MinWidth: 3, MinHeight: 1}
 		return nil
 	}
 
 	// compute cell widths and heights
-	g.gridMap.cellWidths = make([]int, g.w)
-	g.gridMap.cellHeights = make([]int, g.h)
-
-	// distribute width with remainder going to leftmost cells
-	baseWidth := w / g.w
-	widthRemainder := w % g.w
-	for i := 0; i < g.w; i++ {
-		g.gridMap.cellWidths[i] = baseWidth
-		if i < widthRemainder {
-			g.gridMap.cellWidths[i]++
-		}
-	}
-
-	// distribute height with remainder going to topmost cells
-	baseHeight := h / g.h
-	heightRemainder := h % g.h
-	for i := 0; i < g.h; i++ {
-		g.gridMap.cellHeights[i] = baseHeight
-		if i < heightRemainder {
-			g.gridMap.cellHeights[i]++
-		}
-	}
+	g.gridMap.cellWidths = distribute(w, g.w, g.remainder)
+	g.gridMap.cellHeights = distribute(h, g.h, g.remainder)
 
 	// check minimum size requirement and collect resize commands
 	var cmds []tea.Cmd
 	for i, spec := range g.components {
 		// calculate component's actual pixel size
-		compWidth := 0
-		for col := spec.x; col < spec.x+spec.w && col < len(g.gridMap.cellWidths); col++ {
-			compWidth += g.gridMap.cellWidths[col]
-		}
-		compHeight := 0
-		for row := spec.y; row < spec.y+spec.h && row < len(g.gridMap.cellHeights); row++ {
-			compHeight += g.gridMap.cellHeights[row]
-		}
+		compWidth := g.calculateComponentWidth(spec)
+		compHeight := g.calculateComponentHeight(spec)
 
-		// check minimum size (2, 1)
-		if compWidth < 2 || compHeight < 1 {
+		// check minimum size, at least (2, 1)
+		if minW, minH := spec.minSize(); compWidth < minW || compHeight < minH {
+			x, y := g.cellOffset(spec.x, spec.y)
 			g.hasError = true
+			g.err = &LayoutError{
+				Index:     i,
+				X:         x,
+				Y:         y,
+				Width:     compWidth,
+				Height:    compHeight,
+				MinWidth:  minW,
+				MinHeight: minH,
+			}
 			return nil
 		}
 
 		// send resize message to component
-		newComp, cmd := spec.comp.Update(tapioca.ResizeMsg{Width: compWidth, Height: compHeight})
+		newComp, cmd := tapioca.Resize(spec.comp, compWidth, compHeight)
 		g.components[i].comp = newComp
 		if cmd != nil {
 			cmds = append(cmds, cmd)
@@ -200,6 +260,7 @@ func (g *GridLayout) handleResize(w, h int) []tea.Cmd {
 
 	// reset error flag if we got this far
 	g.hasError = false
+	g.err = nil
 	return cmds
 }
 
@@ -276,11 +337,12 @@ func (g *GridLayout) organizeComponentLinesByGridRows(componentLines [][]string)
 		for gridCol := 0; gridCol < g.w; gridCol++ {
 			compIdx := g.gridMap.grid[gridRow][gridCol]
 			if compIdx == -1 {
-				// Empty cell, create empty lines
+				// Empty cell, create blank lines
 				cellHeight := g.gridMap.cellHeights[gridRow]
+				blank := tapioca.BlankLine(g.gridMap.cellWidths[gridCol])
 				gridRows[gridRow][gridCol] = make([]string, cellHeight)
 				for i := range gridRows[gridRow][gridCol] {
-					gridRows[gridRow][gridCol][i] = ""
+					gridRows[gridRow][gridCol][i] = blank
 				}
 			} else {
 				// Cell with component, calculate relative position within component
@@ -341,16 +403,32 @@ func (g *GridLayout) writeCell(result *strings.Builder, cellLines []string, phys
 		line = ""
 	}
 
-	// Truncate or pad to correct width
-	if len(line) >= cellWidth {
-		result.WriteString(line[:cellWidth])
-	} else {
+	// fast path: plain ascii text fits exactly
+	if len(line) == cellWidth && isPlainASCII(line) {
 		result.WriteString(line)
-		// Fill remaining space
-		if remaining := cellWidth - len(line); remaining > 0 {
-			result.WriteString(strings.Repeat(" ", remaining))
+		return
+	}
+
+	// Truncate or pad to correct width, measured in display width
+	result.WriteString(tapioca.NewEntry(line).StyledMove(0, cellWidth))
+}
+
+func isPlainASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 || s[i] == '\x1b' {
+			return false
 		}
 	}
+	return true
+}
+
+// calculateComponentWidth calculates the actual width of a component
+func (g *GridLayout) calculateComponentWidth(spec gridSpec) int {
+	width := 0
+	for col := spec.x; col < spec.x+spec.w && col < len(g.gridMap.cellWidths); col++ {
+		width += g.gridMap.cellWidths[col]
+	}
+	return width
 }
 
 // calculateComponentHeight calculates the actual height of a component
@@ -0,0 +1,157 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DebugView returns a diagnostic description of the grid, for debugging layout
+// issues. It does not affect View() in any way.
+//
+// The output contains the computed cell sizes, a map of which component
+// occupies each cell ("." for empty cells), and the rect assigned to each
+// component. For example, a 3x3 grid of 5 components on a 32x25 terminal:
+//
+//	grid 3x3, size 32x25
+//	cols: 11 11 10
+//	rows: 9 8 8
+//
+//	0 0 1
+//	2 3 1
+//	2 4 4
+//
+//	#0: cell (0,0) span 2x1, rect (0,0) 22x9
+//	...
+func (g *GridLayout) DebugView() string {
+	b := &strings.Builder{}
+
+	totalW, totalH := 0, 0
+	for _, w := range g.gridMap.cellWidths {
+		totalW += w
+	}
+	for _, h := range g.gridMap.cellHeights {
+		totalH += h
+	}
+	resized := len(g.gridMap.cellWidths) > 0 && len(g.gridMap.cellHeights) > 0
+
+	fmt.Fprintf(b, "grid %dx%d", g.w, g.h)
+	if resized {
+		fmt.Fprintf(b, ", size %dx%d", totalW, totalH)
+	} else {
+		b.WriteString(", not resized yet")
+	}
+	if g.hasError {
+		b.WriteString(", too small")
+		if g.err != nil {
+			b.WriteString(": " + g.err.Error())
+		}
+	}
+	b.WriteByte('\n')
+
+	if resized {
+		b.WriteString("cols:")
+		for _, w := range g.gridMap.cellWidths {
+			b.WriteByte(' ')
+			b.WriteString(strconv.Itoa(w))
+		}
+		b.WriteString("\nrows:")
+		for _, h := range g.gridMap.cellHeights {
+			b.WriteByte(' ')
+			b.WriteString(strconv.Itoa(h))
+		}
+		b.WriteByte('\n')
+	}
+
+	// cell map, indexes are right-aligned to the widest one
+	idxWidth := len(strconv.Itoa(max(0, len(g.components)-1)))
+	b.WriteByte('\n')
+	for _, row := range g.gridMap.grid {
+		for col, idx := range row {
+			if col > 0 {
+				b.WriteByte(' ')
+			}
+			cell := "."
+			if idx >= 0 {
+				cell = strconv.Itoa(idx)
+			}
+			b.WriteString(strings.Repeat(" ", idxWidth-len(cell)))
+			b.WriteString(cell)
+		}
+		b.WriteByte('\n')
+	}
+
+	if len(g.components) > 0 {
+		b.WriteByte('\n')
+	}
+	for i, spec := range g.components {
+		fmt.Fprintf(b, "#%d: cell (%d,%d) span %dx%d", i, spec.x, spec.y, spec.w, spec.h)
+		if resized {
+			x, y := g.cellOffset(spec.x, spec.y)
+			fmt.Fprintf(b, ", rect (%d,%d) %dx%d",
+				x, y,
+				g.calculateComponentWidth(spec), g.calculateComponentHeight(spec),
+			)
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// cellOffset computes the position of top-left corner of the cell, in characters
+func (g *GridLayout) cellOffset(col, row int) (x, y int) {
+	for i := 0; i < col && i < len(g.gridMap.cellWidths); i++ {
+		x += g.gridMap.cellWidths[i]
+	}
+	for i := 0; i < row && i < len(g.gridMap.cellHeights); i++ {
+		y += g.gridMap.cellHeights[i]
+	}
+	return
+}
+
+// TraceResize enables or disables recording resize trace, see [LastResize].
+func (g *GridLayout) TraceResize(enable bool) {
+	g.tracing = enable
+	if !enable {
+		g.trace = nil
+	}
+}
+
+// LastResize returns how the space is distributed to components in last resize,
+// including traces of nested layouts which have tracing enabled.
+//
+// It returns nil if tracing is disabled or the grid is not resized yet.
+func (g *GridLayout) LastResize() *ResizeTrace {
+	return g.trace
+}
+
+func (g *GridLayout) recordTrace(w, h int) {
+	if !g.tracing {
+		return
+	}
+
+	g.trace = &ResizeTrace{
+		Layout:   fmt.Sprintf("grid %dx%d", g.w, g.h),
+		Width:    w,
+		Height:   h,
+		TooSmall: g.hasError,
+	}
+	if g.hasError {
+		return
+	}
+	for _, spec := range g.components {
+		x, y := g.cellOffset(spec.x, spec.y)
+		g.trace.Children = append(g.trace.Children, ChildRect{
+			X:      x,
+			Y:      y,
+			Width:  g.calculateComponentWidth(spec),
+			Height: g.calculateComponentHeight(spec),
+			Trace:  childTrace(spec.comp),
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridLayout_DebugView(t *testing.T) {
+	// A A B
+	// C D B
+	// C E E
+	newGrid := func() *GridLayout {
+		grid := NewGridLayout(3, 3)
+		grid.Add(pearl.NewSpan(), 0, 0, 2, 1)
+		grid.Add(pearl.NewSpan(), 2, 0, 1, 2)
+		grid.Add(pearl.NewSpan(), 0, 1, 1, 2)
+		grid.Add(pearl.NewSpan(), 1, 1, 1, 1)
+		grid.Add(pearl.NewSpan(), 1, 2, 2, 1)
+		return grid
+	}
+
+	t.Run("before resize", func(t *testing.T) {
+		grid := NewGridLayout(2, 2)
+		grid.Add(pearl.NewSpan(), 0, 0, 1, 2)
+
+		expect := "grid 2x2, not resized yet\n" +
+			"\n" +
+			"0 .\n" +
+			"0 .\n" +
+			"\n" +
+			"#0: cell (0,0) span 1x2\n"
+		assert.Equal(t, expect, grid.DebugView())
+	})
+
+	t.Run("after resize", func(t *testing.T) {
+		grid := newGrid()
+		grid.Update(tapioca.ResizeMsg{Width: 32, Height: 25})
+
+		expect := "grid 3x3, size 32x25\n" +
+			"cols: 11 11 10\n" +
+			"rows: 9 8 8\n" +
+			"\n" +
+			"0 0 1\n" +
+			"2 3 1\n" +
+			"2 4 4\n" +
+			"\n" +
+			"#0: cell (0,0) span 2x1, rect (0,0) 22x9\n" +
+			"#1: cell (2,0) span 1x2, rect (22,0) 10x17\n" +
+			"#2: cell (0,1) span 1x2, rect (0,9) 11x16\n" +
+			"#3: cell (1,1) span 1x1, rect (11,9) 11x8\n" +
+			"#4: cell (1,2) span 2x1, rect (11,17) 21x8\n"
+		assert.Equal(t, expect, grid.DebugView())
+	})
+
+	t.Run("does not affect view", func(t *testing.T) {
+		grid := newGrid()
+		grid.Update(tapioca.ResizeMsg{Width: 32, Height: 25})
+		before := grid.View()
+		grid.DebugView()
+		assert.Equal(t, before, grid.View())
+	})
+}
@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MinSizer is implemented by components which cannot render below a minimum
+// size. GridLayout refuses to render if such a component gets less space.
+type MinSizer interface {
+	MinSize() (width, height int)
+}
+
+// LayoutError describes why a layout cannot render in its size.
+type LayoutError struct {
+	// Index is the index of the component in order of adding, or -1 if the
+	// whole layout is too small.
+	Index int
+	// X, Y, Width and Height is the rect assigned to the component.
+	X, Y, Width, Height int
+	// MinWidth and MinHeight is the minimum size of the component.
+	MinWidth, MinHeight int
+}
+
+// Shortfall returns how many columns and rows are missing.
+func (e *LayoutError) Shortfall() (width, height int) {
+	return max(0, e.MinWidth-e.Width), max(0, e.MinHeight-e.Height)
+}
+
+func (e *LayoutError) Error() string {
+	w, h := e.Shortfall()
+	if e.Index < 0 {
+		return fmt.Sprintf(
+			"layout %dx%d is smaller than minimum %dx%d, short of %dx%d",
+			e.Width, e.Height, e.MinWidth, e.MinHeight, w, h,
+		)
+	}
+	return fmt.Sprintf(
+		"component #%d at (%d,%d) %dx%d is smaller than minimum %dx%d, short of %dx%d",
+		e.Index, e.X, e.Y, e.Width, e.Height, e.MinWidth, e.MinHeight, w, h,
+	)
+}
+
+// AddWithMin is like Add, but the component requires at least minW columns and
+// minH rows. It takes precedence over [MinSizer] if larger.
+func (g *GridLayout) AddWithMin(comp tea.Model, x, y, w, h, minW, minH int) bool {
+	if !g.Add(comp, x, y, w, h) {
+		return false
+	}
+	spec := &g.components[len(g.components)-1]
+	spec.minW, spec.minH = minW, minH
+	return true
+}
+
+// Err returns why the grid cannot render in last resize, or nil. The error is a
+// *[LayoutError].
+func (g *GridLayout) Err() error {
+	if g.err == nil {
+		return nil
+	}
+	return g.err
+}
+
+// minSize computes minimum size of a component, at least 2x1
+func (spec gridSpec) minSize() (w, h int) {
+	w, h = max(2, spec.minW), max(1, spec.minH)
+	if m, ok := spec.comp.(MinSizer); ok {
+		mw, mh := m.MinSize()
+		w, h = max(w, mw), max(h, mh)
+	}
+	return
+}
@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+type minSpan struct {
+	*pearl.Span
+	w, h int
+}
+
+func (m minSpan) MinSize() (int, int) { return m.w, m.h }
+
+func TestGridLayout_AddWithMin(t *testing.T) {
+	grid := NewGridLayout(3, 2)
+	grid.Add(pearl.NewSpan(), 0, 0, 1, 2)
+	// spans 2x2 cells: 14x6 at size 21x6, just barely fails 15x6
+	assert.True(t, grid.AddWithMin(pearl.NewSpan(), 1, 0, 2, 2, 15, 6))
+
+	grid.Update(tapioca.ResizeMsg{Width: 21, Height: 6})
+	err, ok := grid.Err().(*LayoutError)
+	assert.True(t, ok)
+	assert.Equal(t, &LayoutError{Index: 1, X: 7, Y: 0, Width: 14, Height: 6, MinWidth: 15, MinHeight: 6}, err)
+	w, h := err.Shortfall()
+	assert.Equal(t, 1, w)
+	assert.Equal(t, 0, h)
+	assert.Equal(t, "component #1 at (7,0) 14x6 is smaller than minimum 15x6, short of 1x0", err.Error())
+	assert.Equal(t, "Terminal size too small", grid.View())
+
+	grid.Update(tapioca.ResizeMsg{Width: 23, Height: 6})
+	assert.NoError(t, grid.Err())
+	assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{Width: 23, Height: 6, Model: grid}))
+}
+
+func TestGridLayout_MinSizer(t *testing.T) {
+	grid := NewGridLayout(2, 1)
+	grid.Add(minSpan{Span: pearl.NewSpan(), w: 3, h: 4}, 0, 0, 1, 1)
+	// larger one wins
+	grid.AddWithMin(minSpan{Span: pearl.NewSpan(), w: 3, h: 4}, 1, 0, 1, 1, 5, 2)
+
+	grid.Update(tapioca.ResizeMsg{Width: 10, Height: 3})
+	assert.EqualError(t, grid.Err(), "component #0 at (0,0) 5x3 is smaller than minimum 3x4, short of 0x1")
+
+	grid.Update(tapioca.ResizeMsg{Width: 8, Height: 4})
+	assert.EqualError(t, grid.Err(), "component #1 at (4,0) 4x4 is smaller than minimum 5x4, short of 1x0")
+
+	grid.Update(tapioca.ResizeMsg{Width: 2, Height: 4})
+	assert.EqualError(t, grid.Err(), "layout 2x4 is smaller than minimum 3x1, short of 1x0")
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/pearl"
 	"github.com/raohwork/huninn/tapioca"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -345,3 +346,77 @@ func TestGridLayout_View_ComponentExactSizing(t *testing.T) {
 		})
 	}
 }
+
+func TestGridLayout_WriteCell(t *testing.T) {
+	cases := []struct {
+		name   string
+		line   string
+		width  int
+		expect string
+	}{
+		{"exact", "abc", 3, "abc"},
+		{"pad", "a", 3, "a  "},
+		{"truncate", "abcdef", 3, "abc"},
+		{"exact wide", "你好", 4, "你好"},
+		{"truncate wide", "你好嗎", 4, "你好"},
+		{"half wide char", "你好", 3, "你 "},
+		{"box drawing", "┌──┐", 3, "┌──"},
+		{"exact styled", "\x1b[31mab\x1b[0m", 2, "\x1b[31mab\x1b[0m"},
+		{"truncate styled", "\x1b[31mabcd\x1b[0m", 2, "\x1b[31mab\x1b[0m"},
+		{"missing line", "", 2, "  "},
+	}
+
+	g := NewGridLayout(1, 1)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := &strings.Builder{}
+			lines := []string{c.line}
+			if c.line == "" {
+				lines = nil
+			}
+			g.writeCell(buf, lines, 0, c.width)
+			assert.Equal(t, c.expect, buf.String())
+		})
+	}
+}
+
+func TestGridLayout_View_DisplayWidth(t *testing.T) {
+	// components rendering wrong width are fitted by display width, without
+	// cutting wide characters or escape sequences
+	wide := &MockRenderComponent{}
+	wide.On("Init").Return(nil)
+	wide.On("Update", mock.Anything).Return(wide, nil)
+	wide.On("View").Return("你好嗎")
+	styled := &MockRenderComponent{}
+	styled.On("Init").Return(nil)
+	styled.On("Update", mock.Anything).Return(styled, nil)
+	styled.On("View").Return("\x1b[31mab\x1b[0m")
+
+	grid := NewGridLayout(2, 1)
+	grid.Add(wide, 0, 0, 1, 1)
+	grid.Add(styled, 1, 0, 1, 1)
+	grid.Init()
+	grid.Update(tapioca.ResizeMsg{Width: 8, Height: 1})
+
+	assert.Equal(t, "你好\x1b[31mab\x1b[0m  ", grid.View())
+}
+
+func TestGridLayout_AddBordered(t *testing.T) {
+	grid := NewGridLayout(2, 1)
+	span := pearl.NewSpan()
+	box, ok := grid.AddBordered(span, "Cap", 0, 0, 1, 1)
+	assert.True(t, ok)
+	assert.NotNil(t, box)
+
+	_, ok = grid.AddBordered(pearl.NewSpan(), "Dup", 0, 0, 1, 1)
+	assert.False(t, ok, "overlapped cell should be rejected")
+
+	grid.Init()
+	grid.Update(tapioca.ResizeMsg{Width: 20, Height: 3})
+	lines := strings.Split(grid.View(), "\n")
+	assert.Equal(t, "┌─ Cap ──┐          ", lines[0])
+
+	box.Setter(func(msg tea.Msg) { grid.Update(msg) })("New")
+	lines = strings.Split(grid.View(), "\n")
+	assert.Equal(t, "┌─ New ──┐          ", lines[0])
+}
@@ -236,3 +236,32 @@ func TestGridLayout_HandleResize_CellSizeCalculation(t *testing.T) {
 		})
 	}
 }
+
+func TestGridLayout_RemainderPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RemainderPolicy
+		widths  []int
+		heights []int
+	}{
+		{name: "leading", policy: LeadingCells, widths: []int{17, 17, 16, 16, 16}, heights: []int{3, 3, 2}},
+		{name: "center", policy: CenterCells, widths: []int{16, 17, 17, 16, 16}, heights: []int{3, 3, 2}},
+		{name: "trailing", policy: TrailingCells, widths: []int{16, 16, 16, 17, 17}, heights: []int{2, 3, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := NewGridLayout(5, 3)
+			grid.SetRemainderPolicy(tt.policy)
+			grid.handleResize(82, 8)
+
+			assert.Equal(t, tt.widths, grid.gridMap.cellWidths)
+			assert.Equal(t, tt.heights, grid.gridMap.cellHeights)
+		})
+	}
+
+	t.Run("center keeps symmetry", func(t *testing.T) {
+		assert.Equal(t, []int{2, 3, 3, 3, 2}, distribute(13, 5, CenterCells))
+		assert.Equal(t, []int{2, 3, 3, 2}, distribute(10, 4, CenterCells))
+	})
+}
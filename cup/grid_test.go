@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridLayout_Add_SpanToEdge(t *testing.T) {
+	t.Run("combined", func(t *testing.T) {
+		// A A A
+		// B C C
+		// B C C
+		grid := NewGridLayout(3, 3)
+		assert.True(t, grid.Add(pearl.NewSpan(), 0, 0, -1, 1))
+		assert.True(t, grid.Add(pearl.NewSpan(), 0, 1, 1, -1))
+		assert.True(t, grid.Add(pearl.NewSpan(), 1, 1, -1, -1))
+
+		assert.Equal(t, [][]int{
+			{0, 0, 0},
+			{1, 2, 2},
+			{1, 2, 2},
+		}, grid.gridMap.grid)
+		assert.Equal(t, gridSpec{x: 1, y: 1, w: 2, h: 2, comp: grid.components[2].comp}, grid.components[2])
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		grid := NewGridLayout(3, 2)
+		assert.True(t, grid.Add(pearl.NewSpan(), 2, 0, 1, 1))
+		assert.False(t, grid.Add(pearl.NewSpan(), 0, 0, -1, 1))
+		assert.True(t, grid.Add(pearl.NewSpan(), 0, 1, -1, -1))
+		assert.Len(t, grid.components, 2)
+	})
+}
+
+type resizeHook struct {
+	*pearl.Span
+	w, h int
+}
+
+func (r *resizeHook) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	r.Span.Update(msg)
+	return r, nil
+}
+
+func (r *resizeHook) OnResize(w, h int) { r.w, r.h = w, h }
+
+func TestGridLayout_Resizable(t *testing.T) {
+	hook := &resizeHook{Span: pearl.NewSpan()}
+	grid := NewGridLayout(2, 1)
+	grid.Add(pearl.NewSpan(), 0, 0, 1, 1)
+	grid.Add(hook, 1, 0, 1, 1)
+	grid.Update(tapioca.ResizeMsg{Width: 9, Height: 3})
+
+	assert.Equal(t, 4, hook.w)
+	assert.Equal(t, 3, hook.h)
+}
+
+func TestGridLayout_FillRune(t *testing.T) {
+	defer func(r rune) { tapioca.FillRune = r }(tapioca.FillRune)
+	tapioca.FillRune = '.'
+
+	span := pearl.NewSpan()
+	span.SetContent("ab")
+	grid := NewGridLayout(2, 2)
+	grid.Add(span, 0, 0, 1, 1)
+	grid.Add(PadTop(1, pearl.NewSpan()), 1, 1, 1, 1)
+	grid.Update(tapioca.ResizeMsg{Width: 6, Height: 4})
+
+	assert.Equal(t, ""+
+		"ab ...\n"+
+		"......\n"+
+		"......\n"+
+		"...   ",
+		grid.View())
+}
@@ -0,0 +1,184 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// SplitLayout is like [FixedLayout], but the reserved space can be adjusted
+// by keys at runtime. A divider is drawn between two components.
+//
+// The reserve is clamped to [Min, Max] and the available space, where the
+// other component takes at least 1 column (row). The desired reserve is kept,
+// so it is restored when the terminal grows again.
+//
+// You must create SplitLayout with SplitLeftLayout() or SplitTopLayout().
+type SplitLayout struct {
+	// Min and Max are bounds of the reserve. Max <= 0 means no upper bound.
+	// They take effect on next resize or adjustment.
+	Min, Max int
+	// GrowKey and ShrinkKey are keys to adjust the reserve by 1. Empty string
+	// disables the key. Defaults to ctrl+right/ctrl+left for SplitLeftLayout,
+	// ctrl+down/ctrl+up for SplitTopLayout.
+	//
+	// Other keys are passed to components.
+	GrowKey, ShrinkKey string
+
+	id         int64
+	horizontal bool
+	want       int // desired reserve
+	w, h       int
+	outer      *FixedLayout
+	divider    *splitDivider
+}
+
+// SplitLayoutSetReserveMsg is a message to set the reserve of a SplitLayout.
+type SplitLayoutSetReserveMsg struct {
+	id      int64
+	reserve int
+}
+
+// SplitLeftLayout reserves adjustable space on the left side of the layout.
+func SplitLeftLayout(reserve int, left, right tea.Model) *SplitLayout {
+	return newSplit(true, reserve, left, right)
+}
+
+// SplitTopLayout reserves adjustable space on the top side of the layout.
+func SplitTopLayout(reserve int, top, bottom tea.Model) *SplitLayout {
+	return newSplit(false, reserve, top, bottom)
+}
+
+func newSplit(horizontal bool, reserve int, first, second tea.Model) *SplitLayout {
+	ret := &SplitLayout{
+		Min:        1,
+		GrowKey:    "ctrl+down",
+		ShrinkKey:  "ctrl+up",
+		id:         tapioca.NewID(),
+		horizontal: horizontal,
+		want:       reserve,
+		divider:    &splitDivider{line: '─'},
+	}
+	if horizontal {
+		ret.GrowKey, ret.ShrinkKey = "ctrl+right", "ctrl+left"
+		ret.divider.line = '│'
+	}
+	ret.outer = newFixed(horizontal, false, reserve, first, newFixed(horizontal, false, 1, ret.divider, second))
+	return ret
+}
+
+// SetDivider sets the rune to draw the divider, it must be 1 column wide.
+func (s *SplitLayout) SetDivider(r rune) {
+	s.divider.line = r
+}
+
+// Reserve returns current size of the reserved space.
+func (s *SplitLayout) Reserve() int {
+	return s.outer.reserve
+}
+
+// SetReserve changes the reserved space and resizes components. The value is
+// clamped, see [SplitLayout].
+//
+// You should use it only when you are handling an event message.
+func (s *SplitLayout) SetReserve(n int) tea.Cmd {
+	s.want = n
+	return s.apply()
+}
+
+// Setter returns a function that sends a SplitLayoutSetReserveMsg to change
+// the reserved space.
+func (s *SplitLayout) Setter(send func(tea.Msg)) func(int) {
+	return func(n int) {
+		send(SplitLayoutSetReserveMsg{id: s.id, reserve: n})
+	}
+}
+
+// clamp limits n into bounds with current size
+func (s *SplitLayout) clamp(n int) int {
+	if s.Max > 0 {
+		n = min(n, s.Max)
+	}
+	total := s.h
+	if s.horizontal {
+		total = s.w
+	}
+	// 1 for divider and at least 1 for the other component
+	n = min(n, total-2)
+	return max(n, s.Min, 1)
+}
+
+// apply resizes components if reserve is changed
+func (s *SplitLayout) apply() tea.Cmd {
+	if s.w <= 0 || s.h <= 0 {
+		s.outer.reserve = s.want
+		return nil
+	}
+
+	n := s.clamp(s.want)
+	if n == s.outer.reserve {
+		return nil
+	}
+	s.outer.reserve = n
+	return tea.Batch(s.outer.handleResize(s.w, s.h)...)
+}
+
+func (s *SplitLayout) Init() tea.Cmd {
+	return s.outer.Init()
+}
+
+func (s *SplitLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return s.Update(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		s.w, s.h = msg.Width, msg.Height
+		s.outer.reserve = s.clamp(s.want)
+	case SplitLayoutSetReserveMsg:
+		if msg.id == s.id {
+			return s, s.SetReserve(msg.reserve)
+		}
+		return s, nil
+	case tea.KeyMsg:
+		switch key := msg.String(); {
+		case s.GrowKey != "" && key == s.GrowKey:
+			return s, s.SetReserve(s.Reserve() + 1)
+		case s.ShrinkKey != "" && key == s.ShrinkKey:
+			return s, s.SetReserve(s.Reserve() - 1)
+		}
+	}
+
+	_, cmd := s.outer.Update(msg)
+	return s, cmd
+}
+
+func (s *SplitLayout) View() string {
+	return s.outer.View()
+}
+
+// splitDivider fills its area with a line rune
+type splitDivider struct {
+	line rune
+	w, h int
+}
+
+func (d *splitDivider) Init() tea.Cmd { return nil }
+func (d *splitDivider) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tapioca.ResizeMsg); ok {
+		d.w, d.h = msg.Width, msg.Height
+	}
+	return d, nil
+}
+func (d *splitDivider) View() string {
+	line := strings.Repeat(string(d.line), max(0, d.w))
+	lines := make([]string, max(0, d.h))
+	for i := range lines {
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
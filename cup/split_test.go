@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSplit(horizontal bool, reserve int) *SplitLayout {
+	first, second := pearl.NewSpan(), pearl.NewSpan()
+	first.SetContent("A")
+	second.SetContent("B")
+	if horizontal {
+		return SplitLeftLayout(reserve, first, second)
+	}
+	return SplitTopLayout(reserve, first, second)
+}
+
+func TestSplitLayout_View(t *testing.T) {
+	t.Run("left", func(t *testing.T) {
+		s := newTestSplit(true, 2)
+		s.Update(tapioca.ResizeMsg{Width: 6, Height: 2})
+		assert.Equal(t, "A │B  \n  │   ", s.View())
+		assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  6,
+			Height: 2,
+			Model:  s,
+		}))
+	})
+
+	t.Run("top", func(t *testing.T) {
+		s := newTestSplit(false, 1)
+		s.SetDivider('=')
+		s.Update(tapioca.ResizeMsg{Width: 3, Height: 4})
+		assert.Equal(t, "A  \n===\nB  \n   ", s.View())
+	})
+}
+
+func TestSplitLayout_Keys(t *testing.T) {
+	s := newTestSplit(true, 2)
+	s.Update(tapioca.ResizeMsg{Width: 6, Height: 1})
+
+	s.Update(tea.KeyMsg{Type: tea.KeyCtrlRight})
+	assert.Equal(t, 3, s.Reserve())
+	assert.Equal(t, "A  │B ", s.View())
+
+	s.Update(tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	s.Update(tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	assert.Equal(t, 1, s.Reserve())
+	assert.Equal(t, "A│B   ", s.View())
+
+	s.GrowKey = ""
+	s.Update(tea.KeyMsg{Type: tea.KeyCtrlRight})
+	assert.Equal(t, 1, s.Reserve(), "disabled key")
+}
+
+func TestSplitLayout_Clamp(t *testing.T) {
+	s := newTestSplit(true, 3)
+	s.Min, s.Max = 2, 4
+	s.Update(tapioca.ResizeMsg{Width: 10, Height: 1})
+
+	for range 5 {
+		s.Update(tea.KeyMsg{Type: tea.KeyCtrlRight})
+	}
+	assert.Equal(t, 4, s.Reserve(), "clamped at Max")
+
+	for range 5 {
+		s.Update(tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	}
+	assert.Equal(t, 2, s.Reserve(), "clamped at Min")
+
+	// limited by available space, and restored when it grows
+	setter := s.Setter(func(msg tea.Msg) { s.Update(msg) })
+	setter(4)
+	s.Update(tapioca.ResizeMsg{Width: 5, Height: 1})
+	assert.Equal(t, 3, s.Reserve())
+	assert.Equal(t, "A  │B", s.View())
+	s.Update(tapioca.ResizeMsg{Width: 10, Height: 1})
+	assert.Equal(t, 4, s.Reserve())
+}
@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// StrictBox wraps a component and ensures the output fits exactly the size
+// specified by last ResizeMsg, no matter how the inner component behaves.
+//
+// Lines are padded or truncated to the width, and missing lines are filled with
+// spaces. It makes your dashboard resilient to buggy third-party components, at
+// the cost of measuring every line on every render.
+type StrictBox struct {
+	inner tea.Model
+	w, h  int
+
+	// OnMismatch, if not nil, is called with a descriptive message when the
+	// inner component outputs wrong size. It is called in View(), so it
+	// should not block.
+	OnMismatch func(string)
+}
+
+// NewStrictBox creates a StrictBox wrapping inner.
+func NewStrictBox(inner tea.Model) *StrictBox {
+	return &StrictBox{inner: inner}
+}
+
+func (s *StrictBox) Init() tea.Cmd { return s.inner.Init() }
+
+func (s *StrictBox) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return s.Update(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		s.w, s.h = msg.Width, msg.Height
+		s.inner, cmd = tapioca.Resize(s.inner, msg.Width, msg.Height)
+	default:
+		s.inner, cmd = s.inner.Update(msg)
+	}
+	return s, cmd
+}
+
+func (s *StrictBox) View() string {
+	if s.w <= 0 || s.h <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(s.inner.View(), "\n"), "\n")
+	if len(lines) != s.h {
+		s.report(fmt.Sprintf("expected %d lines, got %d lines", s.h, len(lines)))
+	}
+
+	buf := &strings.Builder{}
+	buf.Grow(s.w*s.h + s.h)
+	for i := 0; i < s.h; i++ {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		if i >= len(lines) {
+			buf.WriteString(tapioca.BlankLine(s.w))
+			continue
+		}
+
+		e := tapioca.NewEntry(lines[i])
+		if e.Width() == s.w {
+			buf.WriteString(lines[i])
+			continue
+		}
+		s.report(fmt.Sprintf("line %d: expected width %d, got width %d", i, s.w, e.Width()))
+		buf.WriteString(e.StyledMove(0, s.w))
+	}
+
+	return buf.String()
+}
+
+func (s *StrictBox) report(msg string) {
+	if s.OnMismatch != nil {
+		s.OnMismatch(msg)
+	}
+}
@@ -0,0 +1,81 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictBox(t *testing.T) {
+	cases := []struct {
+		name     string
+		output   string
+		expected string
+		errors   []string
+	}{
+		{
+			name:     "exact size",
+			output:   "abcd\nefgh",
+			expected: "abcd\nefgh",
+		},
+		{
+			name:     "exact size with tailing newline",
+			output:   "abcd\nefgh\n",
+			expected: "abcd\nefgh",
+		},
+		{
+			name:     "too wide",
+			output:   "abcdef\nefgh",
+			expected: "abcd\nefgh",
+			errors:   []string{"line 0: expected width 4, got width 6"},
+		},
+		{
+			name:     "too narrow with style",
+			output:   "\x1b[31mab\x1b[m\nefgh",
+			expected: "\x1b[31mab\x1b[0m  \nefgh",
+			errors:   []string{"line 0: expected width 4, got width 2"},
+		},
+		{
+			name:     "too few lines",
+			output:   "abcd",
+			expected: "abcd\n    ",
+			errors:   []string{"expected 2 lines, got 1 lines"},
+		},
+		{
+			name:     "too many lines",
+			output:   "abcd\nefgh\nijkl",
+			expected: "abcd\nefgh",
+			errors:   []string{"expected 2 lines, got 3 lines"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			inner := &MockRenderComponent{}
+			inner.On("Update", tapioca.ResizeMsg{Width: 4, Height: 2}).Return(inner, nil)
+			inner.On("View").Return(c.output)
+
+			var errors []string
+			box := NewStrictBox(inner)
+			box.OnMismatch = func(s string) { errors = append(errors, s) }
+			box.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+
+			assert.Equal(t, c.expected, box.View())
+			assert.Equal(t, c.errors, errors)
+		})
+	}
+}
+
+func TestStrictBox_Topping(t *testing.T) {
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  5,
+		Height: 3,
+		Model:  NewStrictBox(pearl.NewSpan()),
+	}))
+}
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ResizeTrace records how a layout distributed its space to children in last
+// resize. It is for debugging nested layouts.
+type ResizeTrace struct {
+	// Layout is a short description of the layout, like "grid 3x3".
+	Layout        string
+	Width, Height int
+	// TooSmall is true if the layout cannot render in the size. Children is
+	// empty in this case.
+	TooSmall bool
+	Children []ChildRect
+}
+
+// ChildRect is the rect assigned to a child, relative to its parent.
+type ChildRect struct {
+	X, Y, Width, Height int
+	// Trace is the resize trace of the child if it is a layout with tracing
+	// enabled, or nil.
+	Trace *ResizeTrace
+}
+
+// resizeTracer is implemented by layouts supporting resize trace.
+type resizeTracer interface {
+	LastResize() *ResizeTrace
+}
+
+func childTrace(m tea.Model) *ResizeTrace {
+	if t, ok := m.(resizeTracer); ok {
+		return t.LastResize()
+	}
+	return nil
+}
+
+// String formats the trace as an indented tree, like
+//
+//	grid 2x1 80x24
+//	  #0: (0,0) 40x24
+//	  #1: (40,0) 40x24 fixed top 1 40x24
+//	    #0: (0,0) 40x1
+//	    #1: (0,1) 40x23
+func (t *ResizeTrace) String() string {
+	b := &strings.Builder{}
+	t.write(b, "")
+	return b.String()
+}
+
+func (t *ResizeTrace) write(b *strings.Builder, indent string) {
+	fmt.Fprintf(b, "%s %dx%d", t.Layout, t.Width, t.Height)
+	if t.TooSmall {
+		b.WriteString(", too small")
+	}
+	b.WriteByte('\n')
+
+	indent += "  "
+	for i, c := range t.Children {
+		fmt.Fprintf(b, "%s#%d: (%d,%d) %dx%d", indent, i, c.X, c.Y, c.Width, c.Height)
+		if c.Trace == nil {
+			b.WriteByte('\n')
+			continue
+		}
+		b.WriteByte(' ')
+		c.Trace.write(b, indent)
+	}
+}
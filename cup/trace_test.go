@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cup
+
+import (
+	"testing"
+
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizeTrace(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		grid := NewGridLayout(1, 1)
+		grid.Add(pearl.NewSpan(), 0, 0, 1, 1)
+		grid.Update(tapioca.ResizeMsg{Width: 10, Height: 5})
+		assert.Nil(t, grid.LastResize())
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		fixed := FixedTopLayout(1, pearl.NewSpan(), pearl.NewSpan())
+		fixed.TraceResize(true)
+		grid := NewGridLayout(2, 1)
+		grid.TraceResize(true)
+		grid.Add(pearl.NewSpan(), 0, 0, 1, 1)
+		grid.Add(fixed, 1, 0, 1, 1)
+		grid.Update(tapioca.ResizeMsg{Width: 81, Height: 24})
+
+		expect := &ResizeTrace{
+			Layout: "grid 2x1",
+			Width:  81,
+			Height: 24,
+			Children: []ChildRect{
+				{X: 0, Y: 0, Width: 41, Height: 24},
+				{X: 41, Y: 0, Width: 40, Height: 24, Trace: &ResizeTrace{
+					Layout: "fixed top 1",
+					Width:  40,
+					Height: 24,
+					Children: []ChildRect{
+						{X: 0, Y: 0, Width: 40, Height: 1},
+						{X: 0, Y: 1, Width: 40, Height: 23},
+					},
+				}},
+			},
+		}
+		assert.Equal(t, expect, grid.LastResize())
+		assert.Equal(t, "grid 2x1 81x24\n"+
+			"  #0: (0,0) 41x24\n"+
+			"  #1: (41,0) 40x24 fixed top 1 40x24\n"+
+			"    #0: (0,0) 40x1\n"+
+			"    #1: (0,1) 40x23\n",
+			grid.LastResize().String())
+	})
+
+	t.Run("fixed right", func(t *testing.T) {
+		fixed := FixedRightLayout(3, pearl.NewSpan(), pearl.NewSpan())
+		fixed.TraceResize(true)
+		fixed.Update(tapioca.ResizeMsg{Width: 10, Height: 2})
+		assert.Equal(t, []ChildRect{
+			{X: 0, Y: 0, Width: 7, Height: 2},
+			{X: 7, Y: 0, Width: 3, Height: 2},
+		}, fixed.LastResize().Children)
+	})
+
+	t.Run("too small", func(t *testing.T) {
+		grid := NewGridLayout(2, 1)
+		grid.TraceResize(true)
+		grid.Add(pearl.NewSpan(), 0, 0, 1, 1)
+		grid.Update(tapioca.ResizeMsg{Width: 2, Height: 1})
+		assert.Equal(t, &ResizeTrace{
+			Layout:   "grid 2x1",
+			Width:    2,
+			Height:   1,
+			TooSmall: true,
+		}, grid.LastResize())
+
+		grid.TraceResize(false)
+		assert.Nil(t, grid.LastResize())
+	})
+}
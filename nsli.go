@@ -40,6 +40,17 @@ func NSLIComponent(tlSize int, logBufferSize int) (
 		w io.Writer,
 		logScroller tapioca.ScrollController,
 	),
+) {
+	m, f, _ := nsliComponent(tlSize, logBufferSize)
+	return m, f
+}
+
+// nsliComponent is NSLIComponent, but also returns the root box so presets can
+// change its border style
+func nsliComponent(tlSize int, logBufferSize int) (
+	tea.Model,
+	func(send func(tea.Msg)) (func(string), pearl.TaskManager, io.Writer, tapioca.ScrollController),
+	*cup.BorderedBox,
 ) {
 	status := pearl.NewSpan()
 	tasks := pearl.NewTaskList()
@@ -58,20 +69,22 @@ func NSLIComponent(tlSize int, logBufferSize int) (
 		tm := tasks.CreateManager(send)
 		w := lp.CreateWriter(send, nil)
 		return setStatus, tm, w, lp.ScrollController()
-	}
+	}, root
 }
 
 func nsli(tlSize, logBufferSize int, opts ...tea.ProgramOption) (
-	prog *tea.Program,
+	prog *program,
 	setStatus func(string),
 	tm pearl.TaskManager,
 	w io.Writer,
 	s tapioca.ScrollController,
+	setBorder func(string),
 ) {
-	m, f := NSLIComponent(tlSize, logBufferSize)
-	prog = tea.NewProgram(m, opts...)
+	m, f, root := nsliComponent(tlSize, logBufferSize)
+	prog = newProgram(m, opts...)
 
 	setStatus, tm, w, s = f(prog.Send)
+	setBorder = root.StyleSetter(prog.Send)
 	return
 }
 
@@ -91,11 +104,17 @@ func NSLI(tlSize, logBufferSize int, opts ...tea.ProgramOption) (
 	w io.Writer,
 	s tapioca.ScrollController,
 ) {
-	app, setStatus, tm, w, s := nsli(tlSize, logBufferSize, opts...)
-	prog = progAsTask(app)
+	app, setStatus, tm, w, s, _ := nsli(tlSize, logBufferSize, opts...)
+	prog = app.task()
 	return
 }
 
+// border styles of LSLI to show job state
+const (
+	borderSuccess = "\x1b[32m"
+	borderError   = "\x1b[31m"
+)
+
 // LSLI (Light Sugar, Light Ice) adds some features to NSLI.
 //
 // It accepts a JobFactory to create a job function, which will be run
@@ -110,28 +129,37 @@ func NSLI(tlSize, logBufferSize int, opts ...tea.ProgramOption) (
 // If you set wait to true, the UI will remain active after the job
 // completes successfully, allowing the user to review the final status
 // and logs. UI always remain active if the job ends with an error.
+// Otherwise, the UI quits after all pending messages are processed, so the
+// last log lines are always shown.
+//
+// Wrap the factory with [WithWatchdog] to warn about a stuck job.
+//
+// The outer border turns green if the job completes successfully, or red if
+// it ends with an error.
 func LSLI(tlSize, logBufferSize int, factory JobFactory, wait bool, opts ...tea.ProgramOption) func(context.Context) error {
-	app, setStatus, tm, w, s := nsli(tlSize, logBufferSize, opts...)
-	job := factory(setStatus, tm, w, s, app.Quit)
+	app, setStatus, tm, w, s, setBorder := nsli(tlSize, logBufferSize, opts...)
+	job := factory(setStatus, tm, w, s, func() { flushAndQuit(app) })
 
 	return func(ctx context.Context) error {
 		jobStopped := make(chan struct{})
 		jobEnd := task.Task(job).
 			Defer(func() { close(jobStopped) }).
 			Go(ctx)
-		appEnd := progAsTask(app).Go(ctx)
+		appEnd := app.task().Go(ctx)
 
 		for {
 			select {
 			case err := <-jobEnd:
 				if err == nil {
+					setBorder(borderSuccess)
 					w.Write([]byte("Job completed successfully.\n"))
 					if !wait {
-						app.Quit()
+						flushAndQuit(app)
 					}
 					continue
 				}
 
+				setBorder(borderError)
 				w.Write([]byte("Job ended with error: " + err.Error() + "\n"))
 				setStatus("Press q or Ctrl+C to exit.")
 			case err := <-appEnd:
@@ -32,6 +32,8 @@ func (m noSuguarComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if s := msg.String(); s == "ctrl+c" || s == "q" {
 			cmd = tea.Quit
 		}
+	case flushMsg:
+		close(msg)
 	default:
 		m.Model, cmd = m.Model.Update(msg)
 	}
@@ -39,6 +41,47 @@ func (m noSuguarComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// flushMsg is closed by noSuguarComponent when processed. Since messages are
+// processed in order, all messages sent before it have been applied by then.
+type flushMsg chan struct{}
+
+// program is a tea.Program which reports when it stops running
+type program struct {
+	*tea.Program
+	stopped chan struct{}
+}
+
+func newProgram(m tea.Model, opts ...tea.ProgramOption) *program {
+	return &program{
+		Program: tea.NewProgram(m, opts...),
+		stopped: make(chan struct{}),
+	}
+}
+
+// task runs the program, it must be called only once
+func (p *program) task() task.Task {
+	return task.FromServer(func() error {
+		defer close(p.stopped)
+		_, err := p.Run()
+		return err
+	}, p.Quit)
+}
+
+// flushAndQuit waits until pending messages are processed, then quits the
+// program, so final log lines are not lost.
+//
+// Messages sent before calling it, by any goroutine, are applied before the
+// program quits. It returns without waiting if the program has stopped.
+func flushAndQuit(app *program) {
+	done := make(flushMsg)
+	app.Send(done)
+	select {
+	case <-done:
+	case <-app.stopped:
+	}
+	app.Quit()
+}
+
 // NSNIComponent returns simplified flavor of huninn UI.
 //
 // The NSNI (No Sugar, No Ice) UI consists of three parts without any decorations:
@@ -89,26 +132,19 @@ func NSNIComponent(tlSize, logBufferSize int) (
 }
 
 func nsni(tlSize, logBufferSize int, opts ...tea.ProgramOption) (
-	prog *tea.Program,
+	prog *program,
 	setStatus func(string),
 	tm pearl.TaskManager,
 	w io.Writer,
 	s tapioca.ScrollController,
 ) {
 	m, f := NSNIComponent(tlSize, logBufferSize)
-	prog = tea.NewProgram(m, opts...)
+	prog = newProgram(m, opts...)
 
 	setStatus, tm, w, s = f(prog.Send)
 	return
 }
 
-func progAsTask(app *tea.Program) task.Task {
-	return task.FromServer(func() error {
-		_, err := app.Run()
-		return err
-	}, app.Quit)
-}
-
 // NSNI (No Sugar, No Ice) wraps NSNIComponent to provide a ready-to-run program.
 //
 // Cancelling the context will stop the program, making it suitable for
@@ -125,7 +161,7 @@ func NSNI(tlSize, logBufferSize int, opts ...tea.ProgramOption) (
 	s tapioca.ScrollController,
 ) {
 	app, setStatus, tm, w, s := nsni(tlSize, logBufferSize, opts...)
-	prog = progAsTask(app)
+	prog = app.task()
 	return
 }
 
@@ -138,6 +174,10 @@ func NSNI(tlSize, logBufferSize int, opts ...tea.ProgramOption) (
 //   - logScroller: a scroll controller to control log panel component.
 //   - quit: a function to terminate the UI program.
 //
+// Messages sent through the parameters before calling quit() or returning
+// from the job function are always processed and rendered before the UI
+// program terminates.
+//
 // You SHOULD NOT use quit() in most cases, returning from the job
 // function is enough. But when you have to, you MUST remember returning
 // from the job function after calling quit() ASAP, or the program
@@ -164,16 +204,20 @@ type JobFactory func(
 // If you set wait to true, the UI will remain active after the job
 // completes successfully, allowing the user to review the final status
 // and logs. UI always remain active if the job ends with an error.
+// Otherwise, the UI quits after all pending messages are processed, so the
+// last log lines are always shown.
+//
+// Wrap the factory with [WithWatchdog] to warn about a stuck job.
 func LSNI(tlSize, logBufferSize int, factory JobFactory, wait bool, opts ...tea.ProgramOption) func(context.Context) error {
 	app, setStatus, tm, w, s := nsni(tlSize, logBufferSize, opts...)
-	job := factory(setStatus, tm, w, s, app.Quit)
+	job := factory(setStatus, tm, w, s, func() { flushAndQuit(app) })
 
 	return func(ctx context.Context) error {
 		jobStopped := make(chan struct{})
 		jobEnd := task.Task(job).
 			Defer(func() { close(jobStopped) }).
 			Go(ctx)
-		appEnd := progAsTask(app).Go(ctx)
+		appEnd := app.task().Go(ctx)
 
 		for {
 			select {
@@ -181,7 +225,7 @@ func LSNI(tlSize, logBufferSize int, factory JobFactory, wait bool, opts ...tea.
 				if err == nil {
 					w.Write([]byte("Job completed successfully.\n"))
 					if !wait {
-						app.Quit()
+						flushAndQuit(app)
 					}
 					continue
 				}
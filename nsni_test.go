@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package huninn
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// msgCounter counts int messages it receives
+type msgCounter struct {
+	n int
+}
+
+func (c *msgCounter) Init() tea.Cmd { return nil }
+func (c *msgCounter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(int); ok {
+		c.n++
+	}
+	return c, nil
+}
+func (c *msgCounter) View() string { return "" }
+
+func newTestProgram(m tea.Model) *program {
+	return newProgram(m,
+		tea.WithInput(nil),
+		tea.WithOutput(io.Discard),
+		tea.WithoutRenderer(),
+		tea.WithoutSignalHandler(),
+	)
+}
+
+func TestFlushAndQuit(t *testing.T) {
+	counter := &msgCounter{}
+	app := newTestProgram(noSuguarComponent{counter})
+	end := app.task().Go(context.Background())
+
+	// messages from several goroutines, all sent before flushAndQuit
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				app.Send(i)
+			}
+		}()
+	}
+	wg.Wait()
+	flushAndQuit(app)
+
+	assert.NoError(t, <-end)
+	assert.Equal(t, 1000, counter.n)
+}
+
+func TestFlushAndQuit_Stopped(t *testing.T) {
+	app := newTestProgram(noSuguarComponent{&msgCounter{}})
+	end := app.task().Go(context.Background())
+	app.Quit()
+	assert.NoError(t, <-end)
+
+	done := make(chan struct{})
+	go func() {
+		flushAndQuit(app)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("flushAndQuit should return once the program has stopped")
+	}
+}
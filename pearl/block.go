@@ -47,6 +47,7 @@ func (b *Block) SetContent(data ...string) {
 		b.entries[i] = tapioca.NewEntry(line)
 		b.maxWidth = max(b.maxWidth, b.entries[i].Width())
 	}
+	b.Clamp()
 }
 
 // Setter returns a function that sends a BlockSetContentMsg to update the Block's content.
@@ -67,7 +68,7 @@ func (b *Block) View() string {
 		lines = append(lines, b.entries[i].StyledMove(b.X(), b.Width()))
 	}
 	for i := len(lines); i < b.Height(); i++ {
-		lines = append(lines, strings.Repeat(" ", b.Width()))
+		lines = append(lines, tapioca.BlankLine(b.Width()))
 	}
 	return strings.Join(lines, "\n")
 }
@@ -77,6 +78,8 @@ func (b *Block) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 func (b *Block) UpdateInto(msg tea.Msg) (*Block, tea.Cmd) {
 	switch m := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.HandleEvent(tapioca.ResizeMsg{Width: m.Width, Height: m.Height})
 	case BlockSetContentMsg:
 		if m.id != b.id {
 			return b, nil
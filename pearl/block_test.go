@@ -47,3 +47,34 @@ func TestBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestBlock_WindowSizeMsg(t *testing.T) {
+	b := NewBlock()
+	b.SetContent("hello")
+	b.Update(tea.WindowSizeMsg{Width: 7, Height: 2})
+	assert.Equal(t, "hello  \n       ", b.View())
+}
+
+func TestBlock_ClampAfterSetContent(t *testing.T) {
+	b := NewBlock()
+	b.SetContent("0123456789", "1", "2", "3", "4")
+	b.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	b.ScrollTo(6, 3)
+	assert.Equal(t, 6, b.X())
+	assert.Equal(t, 3, b.Y())
+
+	b.SetContent("abc")
+	assert.Equal(t, 0, b.X())
+	assert.Equal(t, 0, b.Y())
+	assert.Equal(t, "abc \n    ", b.View())
+}
+
+func TestBlock_FillRune(t *testing.T) {
+	defer func(r rune) { tapioca.FillRune = r }(tapioca.FillRune)
+	tapioca.FillRune = '.'
+
+	b := NewBlock()
+	b.SetContent("ab")
+	b.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	assert.Equal(t, "ab  \n....", b.View())
+}
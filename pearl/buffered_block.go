@@ -11,11 +11,46 @@ import "github.com/raohwork/huninn/tapioca"
 //
 // If you need proper control over text display, you might want to take a look
 // at [tapioca.Entry].
+//
+// BufferedBlock is not safe for concurrent use. Like other components, all of
+// its methods including View must be called on the goroutine running the
+// Bubble Tea program, which is where Update and View of your model are called.
+// Other goroutines should send messages instead, like what the writer of
+// [LogPanel.CreateWriter] does.
 type BufferedBlock struct {
 	// enable horizontal scroll, will disable line wrap
 	hScroll bool
 	// enable vertical scroll
 	vScroll bool
+	// how entries are broken into lines, used only if hScroll is false
+	wrapPolicy tapioca.WrapPolicy
+	// if not nil, only entries passing the filter are displayed
+	filter func(*tapioca.Entry) bool
+	// number of newest entries pinned at bottom of the viewport
+	pin int
+	// scroll to bottom when appending if viewport is at bottom
+	follow bool
+	// if not empty, entries are rendered as aligned columns joined by it
+	colSep string
+	// render RTL entries in visual order, right aligned
+	bidi bool
+	// show a minimap at right side, which takes 1 column
+	minimap bool
+	// show a scrollbar at rightmost column if vScroll is enabled
+	scrollbar bool
+	// show entry numbers in a gutter at left side
+	numbers bool
+	// width of the gutter, 0 if not shown
+	gutter int
+	// width including gutter, minimap and scrollbar
+	fullWidth int
+	// background of alternate rows
+	zebra ZebraStyle
+	// shown at center when there's no entry, nil if not set
+	placeholder *tapioca.Entry
+	// highlighted match, nil if not set
+	match      *entryMatch
+	matchStyle tapioca.HighlightStyle
 
 	tapioca.Scrollable
 
@@ -35,9 +70,29 @@ type BufferedBlock struct {
 // Append adds a new entry to the end of the virtual screen. In a log panel context,
 // this would add a new log message at the bottom (older messages are shown by default
 // since the viewport starts at position 0,0).
+//
+// If following is enabled, see [BufferedBlock.SetFollow], the viewport is
+// scrolled to the bottom.
 func (c *BufferedBlock) Append(str string) {
-	c.entries.Append(tapioca.NewEntry(str))
+	c.AppendEntry(tapioca.NewEntry(str))
+}
+
+// AppendReport is like Append, but reports whether the buffer was full and the
+// oldest entry is dropped.
+func (c *BufferedBlock) AppendReport(str string) (evicted bool) {
+	evicted = c.entries.Size() == c.entries.Capacity()
+	c.Append(str)
+	return
+}
+
+// AppendEntry is like Append, but accepts a prebuilt entry.
+func (c *BufferedBlock) AppendEntry(e *tapioca.Entry) {
+	follow := c.following()
+	c.entries.Append(e)
 	c.recomputeCachedInfo()
+	if follow {
+		c.ScrollToBottom()
+	}
 }
 
 // Prepend adds a new entry to the beginning of the virtual screen. In a log panel
@@ -48,9 +103,16 @@ func (c *BufferedBlock) Prepend(str string) {
 	c.recomputeCachedInfo()
 }
 
+// PrependEntry is like Prepend, but accepts a prebuilt entry.
+func (c *BufferedBlock) PrependEntry(e *tapioca.Entry) {
+	c.entries.Prepend(e)
+	c.recomputeCachedInfo()
+}
+
 // Clear removes all entries from the component.
 func (c *BufferedBlock) Clear() {
 	c.entries.Reset()
+	c.match = nil
 	c.recomputeCachedInfo()
 }
 
@@ -59,14 +121,193 @@ func (c *BufferedBlock) Entries() []*tapioca.Entry {
 	return c.entries.GetAll()
 }
 
+// SetFilter sets a function to decide which entries are displayed. Entries
+// are still stored even if they are filtered out. Pass nil to show all entries.
+func (c *BufferedBlock) SetFilter(f func(*tapioca.Entry) bool) {
+	c.filter = f
+	c.recomputeCachedInfo()
+}
+
+// visibleEntries returns entries passing the filter
+func (c *BufferedBlock) visibleEntries() []*tapioca.Entry {
+	entries := c.entries.GetAll()
+	if c.filter == nil {
+		return entries
+	}
+
+	ret := make([]*tapioca.Entry, 0, len(entries))
+	for _, e := range entries {
+		if c.filter(e) {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+// EntryIndexAtRow maps a row of the viewport (0 is the top row) to the index of
+// the entry displayed there, considering line wrap and vertical scroll. The
+// index is the position in Entries().
+//
+// It returns false if the row is out of viewport or is a blank row.
+func (c *BufferedBlock) EntryIndexAtRow(row int) (entryIdx int, ok bool) {
+	if row < 0 || row >= c.Height() {
+		return 0, false
+	}
+
+	all := c.entries.GetAll()
+	visible := c.visibleIndexes(all)
+
+	rows := min(c.pin, c.Height())
+	history := visible[:max(0, len(visible)-rows)]
+	if top := c.Height() - rows; row >= top {
+		// pinned region, entries are aligned to the bottom
+		pinned := visible[len(history):]
+		k := row - top - (rows - len(pinned))
+		if k < 0 {
+			return 0, false
+		}
+		return pinned[k], true
+	}
+
+	line := c.Y() + row // line on virtual screen
+	cur := 0
+	for _, idx := range history {
+		h := 1
+		if !c.hScroll && c.colSep == "" {
+			h = all[idx].LinesWith(c.Width(), c.wrapPolicy)
+		}
+		if line < cur+h {
+			return idx, true
+		}
+		cur += h
+	}
+	return 0, false
+}
+
+// SetFollow makes Append scroll to the bottom to show new entry, only if the
+// viewport is already at the bottom. Once user scrolls up to read history, the
+// viewport stays there until scrolled back to the bottom. Default to false.
+func (c *BufferedBlock) SetFollow(enable bool) {
+	c.follow = enable
+}
+
+// Follow reports whether following is enabled by SetFollow.
+func (c *BufferedBlock) Follow() bool {
+	return c.follow
+}
+
+// following reports whether new entries should scroll the viewport
+func (c *BufferedBlock) following() bool {
+	return c.follow && c.AtBottom()
+}
+
+// AtBottom reports whether the viewport is scrolled to the bottom.
+func (c *BufferedBlock) AtBottom() bool {
+	return c.Y() >= c.ExtentV()-c.Height()
+}
+
 // Capacity returns the maximum number of entries the component can hold.
 func (c *BufferedBlock) Capacity() int {
 	return c.entries.Capacity()
 }
 
-// ResizeBuffer changes the capacity of the entries buffer to newSize.
+// ResizeBuffer changes the capacity of the entries buffer to newSize. Oldest
+// entries are removed if there are more than newSize entries.
 func (c *BufferedBlock) ResizeBuffer(newSize int) {
 	c.entries.Resize(newSize)
+	c.recomputeCachedInfo()
+}
+
+// SetWrapPolicy changes how entries are broken into lines when line wrap is
+// enabled (hScroll is false). Default to [tapioca.BreakAnywhere].
+//
+// With [tapioca.Overflow], lines contain a long word can be scrolled horizontally.
+func (c *BufferedBlock) SetWrapPolicy(p tapioca.WrapPolicy) {
+	c.wrapPolicy = p
+	c.recomputeCachedInfo()
+	c.ScrollLeft(c.X())
+}
+
+// SetHorizontalScrollable changes hScroll set in NewBufferedBlock. Enabling it
+// disables line wrap.
+//
+// Horizontal scroll position is reset, vertical one is clamped to new range.
+func (c *BufferedBlock) SetHorizontalScrollable(enable bool) {
+	c.hScroll = enable
+	c.recomputeCachedInfo()
+	c.ScrollTo(0, c.Y())
+}
+
+// SetVerticalScrollable changes vScroll set in NewBufferedBlock. Disabling it
+// scrolls to top.
+func (c *BufferedBlock) SetVerticalScrollable(enable bool) {
+	c.vScroll = enable
+	if c.fullWidth > 0 {
+		// scrollbar is shown only if vScroll is enabled
+		c.resize(c.fullWidth, c.Height())
+	} else {
+		c.recomputeCachedInfo()
+	}
+	if !enable {
+		c.ScrollToTop()
+	}
+}
+
+// SetLineWrap switches between line wrap mode and horizontal scroll mode, it is
+// identical to SetHorizontalScrollable(!wrap).
+func (c *BufferedBlock) SetLineWrap(wrap bool) {
+	c.SetHorizontalScrollable(!wrap)
+}
+
+// LineWrap reports whether line wrap is enabled.
+func (c *BufferedBlock) LineWrap() bool {
+	return !c.hScroll
+}
+
+// PinNewest keeps the newest n entries at the bottom n rows of the viewport,
+// one row per entry, no matter where the history above them is scrolled to.
+// Pinned entries are not wrapped. Pass 0 to disable it.
+func (c *BufferedBlock) PinNewest(n int) {
+	c.pin = max(0, n)
+	c.recomputeCachedInfo()
+}
+
+// SetColumnize renders entries as aligned columns, like "column -t". Pass an
+// empty sep to disable it.
+//
+// Each entry is split on runs of whitespace, and cells are padded to the width
+// of widest cell in same column, which is computed from entries currently in
+// the viewport. Cells in a row are joined by sep. In column mode, every entry
+// takes exactly one row, it is not wrapped nor scrolled horizontally, and the
+// overflowing part (usually the last column) is truncated.
+func (c *BufferedBlock) SetColumnize(sep string) {
+	c.colSep = sep
+	c.recomputeCachedInfo()
+	c.ScrollLeft(c.X())
+}
+
+// SetBidi enables basic right-to-left text support: entries detected as RTL by
+// [tapioca.Entry.IsRTL] are displayed in visual order and right aligned. When
+// scrolled horizontally, RTL entries are scrolled from right edge.
+//
+// It only handles whole-line RTL, see [tapioca.Entry.Visual] for limitations.
+// Column mode is not affected.
+func (c *BufferedBlock) SetBidi(enable bool) {
+	c.bidi = enable
+}
+
+// SetEmptyPlaceholder sets a message shown dim at center of the component when
+// there's no entry, like "No logs yet…". It is truncated if the component is
+// too narrow. Pass an empty string to show a blank screen, which is the
+// default.
+//
+// Entries hidden by the filter are still entries, so the placeholder is not
+// shown in that case.
+func (c *BufferedBlock) SetEmptyPlaceholder(msg string) {
+	c.placeholder = nil
+	if msg != "" {
+		c.placeholder = tapioca.NewEntry("\x1b[2m" + msg + "\x1b[0m")
+	}
 }
 
 // NewBufferedBlock creates a new component with the specified entry capacity.
@@ -74,9 +315,10 @@ func (c *BufferedBlock) ResizeBuffer(newSize int) {
 // When the buffer is full, adding new entries will overwrite the oldest ones.
 func NewBufferedBlock(size int, hScroll, vScroll bool) *BufferedBlock {
 	ret := &BufferedBlock{
-		entries: tapioca.NewCircularBuffer[*tapioca.Entry](size),
-		hScroll: hScroll,
-		vScroll: vScroll,
+		entries:    tapioca.NewCircularBuffer[*tapioca.Entry](size),
+		hScroll:    hScroll,
+		vScroll:    vScroll,
+		matchStyle: tapioca.HighlightStyle{Reverse: true},
 	}
 	ret.Scrollable = tapioca.NewScrollable(
 		func() int { return ret.maxLineWidth },
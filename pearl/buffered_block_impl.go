@@ -5,6 +5,7 @@
 package pearl
 
 import (
+	"iter"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -29,19 +30,27 @@ func (c *BufferedBlock) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (c *BufferedBlock) UpdateInto(msg tea.Msg) (*BufferedBlock, tea.Cmd) {
 	var cmd []tea.Cmd
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return c.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
 	case tapioca.ResizeMsg:
-		c.HandleEvent(msg)
-		c.recomputeCachedInfo()
+		c.resize(msg.Width, msg.Height)
 	case tapioca.ScrollUpMsg,
 		tapioca.ScrollDownMsg,
 		tapioca.ScrollLeftMsg,
 		tapioca.ScrollRightMsg,
 		tapioca.ScrollTopMsg,
 		tapioca.ScrollBottomMsg,
+		tapioca.ScrollPageUpMsg,
+		tapioca.ScrollPageDownMsg,
 		tapioca.ScrollBeginMsg,
 		tapioca.ScrollEndMsg,
-		tapioca.ScrollToMsg:
+		tapioca.ScrollToMsg,
+		tapioca.ScrollByMsg:
 		c.HandleEvent(msg)
+	case ScrollToEntryMsg:
+		c.ScrollToEntry(msg.Index)
+	case HighlightMatchMsg:
+		c.HighlightMatch(msg.Index, msg.Col, msg.Width)
 	}
 
 	if len(cmd) == 0 {
@@ -58,107 +67,328 @@ func (c *BufferedBlock) View() string {
 		return ""
 	}
 
-	entries := c.entries.GetAll()
+	entries := c.visibleEntries()
+	c.highlightMatch(entries)
+	lines := c.viewLines(entries)
+	if c.gutter > 0 {
+		c.prependGutter(lines)
+	}
+	if c.zebra.Background != "" {
+		c.applyZebra(lines)
+	}
+	if c.showMinimap() {
+		c.appendMinimap(lines, entries)
+	}
+	if c.showScrollbar() {
+		c.appendScrollbar(lines)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// viewLines renders the content area, without minimap and scrollbar
+func (c *BufferedBlock) viewLines(entries []*tapioca.Entry) []string {
 	if len(entries) == 0 {
 		// No entries, return blank screen
-		return c.blankScreen()
+		lines := c.blankScreen()
+		if c.placeholder != nil && c.entries.Size() == 0 {
+			lines[(len(lines)-1)/2] = c.placeholderLine()
+		}
+		return lines
+	}
+
+	history, pinned, rows := c.splitPinned(entries)
+	height := c.Height() - rows
+	if c.colSep != "" {
+		return c.viewColumns(history, pinned, height, rows)
+	}
+
+	lines := make([]string, 0, c.Height())
+	if height > 0 {
+		// hScroll controls whether wrapping is enabled
+		if c.hScroll {
+			// No-wrap mode (may have horizontal scrolling)
+			lines = c.viewNoWrap(history, height)
+		} else {
+			// Wrap mode (may have vertical scrolling)
+			lines = c.viewWrap(history, height)
+		}
+	}
+	if rows > 0 {
+		lines = append(lines, c.viewPinned(pinned, rows)...)
+	}
+	return lines
+}
+
+// splitPinned separates entries into history and pinned ones, rows is the
+// height of pinned region.
+func (c *BufferedBlock) splitPinned(entries []*tapioca.Entry) (history, pinned []*tapioca.Entry, rows int) {
+	rows = min(c.pin, c.Height())
+	if rows <= 0 {
+		return entries, nil, 0
 	}
 
-	// hScroll controls whether wrapping is enabled
+	n := max(0, len(entries)-rows)
+	return entries[:n], entries[n:], rows
+}
+
+// viewPinned renders pinned entries, one row per entry, at the bottom of rows
+func (c *BufferedBlock) viewPinned(pinned []*tapioca.Entry, rows int) []string {
+	x := 0
 	if c.hScroll {
-		// No-wrap mode (may have horizontal scrolling)
-		return c.viewNoWrap(entries)
-	} else {
-		// Wrap mode (may have vertical scrolling)
-		return c.viewWrap(entries)
+		x = c.X()
+	}
+
+	lines := make([]string, 0, rows)
+	for i := len(pinned); i < rows; i++ {
+		lines = append(lines, tapioca.BlankLine(c.Width()))
+	}
+	for _, e := range pinned {
+		lines = append(lines, c.displayLine(e, x))
+	}
+	return lines
+}
+
+// viewColumns renders entries in viewport as aligned columns, see SetColumnize.
+func (c *BufferedBlock) viewColumns(history, pinned []*tapioca.Entry, height, rows int) []string {
+	start := min(c.Y(), len(history))
+	shown := history[start:min(start+max(0, height), len(history))]
+	cells := make([][]*tapioca.Entry, 0, len(shown)+len(pinned))
+	for _, e := range shown {
+		cells = append(cells, e.Fields())
+	}
+	for _, e := range pinned {
+		cells = append(cells, e.Fields())
+	}
+	columns := alignColumns(cells, c.colSep)
+
+	blank := tapioca.BlankLine(c.Width())
+	lines := make([]string, 0, c.Height())
+	for i := range max(0, height) {
+		if i < len(shown) {
+			lines = append(lines, columns[i].StyledMove(0, c.Width()))
+		} else {
+			lines = append(lines, blank)
+		}
+	}
+	for i := len(pinned); i < rows; i++ {
+		lines = append(lines, blank)
+	}
+	for _, e := range columns[len(shown):] {
+		lines = append(lines, e.StyledMove(0, c.Width()))
+	}
+	return lines
+}
+
+// alignColumns pads every cell to the width of widest one in same column, and
+// joins cells of a row with sep. Last cell of a row is not padded.
+func alignColumns(rows [][]*tapioca.Entry, sep string) []*tapioca.Entry {
+	var widths []int
+	for _, cells := range rows {
+		for i, cell := range cells {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], cell.Width())
+		}
 	}
+
+	ret := make([]*tapioca.Entry, len(rows))
+	buf := &strings.Builder{}
+	for idx, cells := range rows {
+		buf.Reset()
+		for i, cell := range cells {
+			if i > 0 {
+				buf.WriteString(sep)
+			}
+			buf.WriteString(cell.StyledString())
+			if i < len(cells)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[i]-cell.Width()))
+			}
+		}
+		ret[idx] = tapioca.NewEntry(buf.String())
+	}
+	return ret
 }
 
-func (c *BufferedBlock) blankScreen() string {
-	line := strings.Repeat(" ", c.Width())
+// placeholderLine renders the placeholder centered in a line
+func (c *BufferedBlock) placeholderLine() string {
+	w := c.placeholder.Width()
+	if w > c.Width() {
+		return c.placeholder.StyledTruncate(c.Width(), '…')
+	}
+	return c.placeholder.StyledMovePad(-(c.Width()-w)/2, c.Width(), tapioca.FillRune)
+}
+
+func (c *BufferedBlock) blankScreen() []string {
+	line := tapioca.BlankLine(c.Width())
 	lines := make([]string, c.Height())
 	for i := range lines {
 		lines[i] = line
 	}
-	return strings.Join(lines, "\n")
+	return lines
 }
 
-func (c *BufferedBlock) viewWrap(entries []*tapioca.Entry) string {
-	lines := make([]string, 0, c.Y()+c.Height())
+func (c *BufferedBlock) viewWrap(entries []*tapioca.Entry, height int) []string {
+	lines := make([]string, 0, c.Y()+height)
 	totalEntries := len(entries)
 
 	curLine, curIdx := 0, 0
 	// fill lines
-	for curLine < c.Y()+c.Height() && curIdx < totalEntries {
+	for curLine < c.Y()+height && curIdx < totalEntries {
 		entry := entries[curIdx]
-		l := entry.StyledBlock(c.Width())
+		l := c.wrapEntry(entry)
 		h := len(l)
 
-		want := min(h, c.Y()+c.Height()-curLine)
+		want := min(h, c.Y()+height-curLine)
 		lines = append(lines, l[:want]...)
 		curIdx++
 		curLine += want
 	}
-	if curLine < c.Y()+c.Height() {
-		padLine := strings.Repeat(" ", c.Width())
-		for curLine < c.Y()+c.Height() {
+	if curLine < c.Y()+height {
+		padLine := tapioca.BlankLine(c.Width())
+		for curLine < c.Y()+height {
 			lines = append(lines, padLine)
 			curLine++
 		}
 	}
 
-	return strings.Join(lines[c.Y():], "\n")
+	return lines[c.Y():]
+}
+
+// wrapEntry breaks the entry into lines according to wrap policy
+func (c *BufferedBlock) wrapEntry(entry *tapioca.Entry) []string {
+	if c.bidi && entry.IsRTL() {
+		lines := entry.Wrap(c.Width(), c.wrapPolicy)
+		ret := make([]string, len(lines))
+		for i, l := range lines {
+			ret[i] = c.displayLine(l, c.X())
+		}
+		return ret
+	}
+	if c.wrapPolicy != tapioca.Overflow {
+		return entry.StyledBlockWith(c.Width(), c.wrapPolicy)
+	}
+
+	lines := entry.Wrap(c.Width(), c.wrapPolicy)
+	ret := make([]string, len(lines))
+	for i, l := range lines {
+		ret[i] = c.displayLine(l, c.X())
+	}
+	return ret
+}
+
+// displayLine renders a single line of entry starting at column x, RTL entries
+// are right aligned and x is counted from right edge if bidi is enabled
+func (c *BufferedBlock) displayLine(e *tapioca.Entry, x int) string {
+	if !c.bidi || !e.IsRTL() {
+		return e.StyledMove(x, c.Width())
+	}
+
+	v := e.Visual()
+	return v.StyledMove(v.Width()-c.Width()-x, c.Width())
 }
 
-func (c *BufferedBlock) viewNoWrap(entries []*tapioca.Entry) string {
+func (c *BufferedBlock) viewNoWrap(entries []*tapioca.Entry, height int) []string {
 	if c.X()+c.Width() > c.maxLineWidth {
 		c.ScrollToBegin()
 		c.ScrollRight(c.maxLineWidth - c.Width())
 	}
 
-	wantedEntries := entries[c.Y():min(c.Y()+c.Height(), len(entries))]
-	lines := make([]string, c.Height())
+	start := min(c.Y(), len(entries))
+	wantedEntries := entries[start:min(start+height, len(entries))]
+	lines := make([]string, height)
 	for i := range wantedEntries {
-		lines[i] = wantedEntries[i].StyledMove(c.X(), c.Width())
+		lines[i] = c.displayLine(wantedEntries[i], c.X())
 	}
-	for i := len(wantedEntries); i < c.Height(); i++ {
-		lines[i] = strings.Repeat(" ", c.Width())
+	for i := len(wantedEntries); i < height; i++ {
+		lines[i] = tapioca.BlankLine(c.Width())
 	}
 
-	return strings.Join(lines, "\n")
+	return lines
 }
 
 func (c *BufferedBlock) recomputeCachedInfo() {
-	entries := c.entries.GetAll()
-	c.recomputeLines(entries)
-	c.recomputeMaxLineWidth(entries)
+	if c.fullWidth > 0 {
+		// gutter might grow with number of entries
+		if w := c.layoutWidth(); w != c.Width() {
+			c.HandleEvent(tapioca.ResizeMsg{Width: w, Height: c.Height()})
+		}
+	}
+
+	c.recomputeLines()
+	c.recomputeMaxLineWidth()
+	c.Clamp()
 }
 
-func (c *BufferedBlock) recomputeLines(entries []*tapioca.Entry) {
-	if c.hScroll {
-		// When horizontal scrolling is enabled, no wrapping occurs,
-		// so virtual screen line count equals number of entries
-		c.lines = c.entries.Size()
-	} else {
-		// When horizontal scrolling is disabled, entries wrap,
-		// so virtual screen line count is total lines after wrapping
-		c.lines = 0
-		for _, e := range entries {
-			c.lines += e.Lines(c.Width())
+// visible iterates over entries passing the filter without allocating
+func (c *BufferedBlock) visible() iter.Seq[*tapioca.Entry] {
+	return func(yield func(*tapioca.Entry) bool) {
+		for _, e := range c.entries.All() {
+			if (c.filter == nil || c.filter(e)) && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func (c *BufferedBlock) recomputeLines() {
+	// pinned entries are not scrollable, count them as the rows they take so
+	// history can be scrolled within the rest of the viewport
+	rows := max(0, min(c.pin, c.Height()))
+	history := c.entries.Size()
+	if c.filter != nil {
+		history = 0
+		for range c.visible() {
+			history++
 		}
 	}
+	history -= rows
+
+	c.lines = 0
+	for e := range c.visible() {
+		if history <= 0 {
+			break
+		}
+		history--
 
-	// Virtual screen line count should be at least the physical screen height
-	c.lines = max(c.lines, c.Height())
+		if c.hScroll || c.colSep != "" {
+			// When horizontal scrolling or column mode is enabled, no wrapping
+			// occurs, so virtual screen line count equals number of entries
+			c.lines++
+			continue
+		}
+		// When horizontal scrolling is disabled, entries wrap, so virtual
+		// screen line count is total lines after wrapping
+		c.lines += e.LinesWith(c.Width(), c.wrapPolicy)
+	}
+
+	// Virtual screen line count should be at least the physical screen height,
+	// history fills the rows above pinned region
+	c.lines = max(c.lines, c.Height()-rows) + rows
 }
 
-func (c *BufferedBlock) recomputeMaxLineWidth(entries []*tapioca.Entry) {
+func (c *BufferedBlock) recomputeMaxLineWidth() {
 	c.maxLineWidth = c.Width()
+	if c.colSep != "" {
+		// column mode does not scroll horizontally
+		return
+	}
 	if !c.hScroll {
+		if c.wrapPolicy == tapioca.Overflow {
+			// long words might exceed the width
+			for e := range c.visible() {
+				if e.Width() <= c.Width() {
+					continue
+				}
+				for _, l := range e.Wrap(c.Width(), c.wrapPolicy) {
+					c.maxLineWidth = max(c.maxLineWidth, l.Width())
+				}
+			}
+		}
 		return
 	}
 
-	for _, e := range entries {
+	for e := range c.visible() {
 		if l := e.Width(); c.maxLineWidth < l {
 			c.maxLineWidth = l
 		}
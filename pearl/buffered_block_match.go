@@ -0,0 +1,134 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import "github.com/raohwork/huninn/tapioca"
+
+// ScrollToEntryMsg tells BufferedBlock to scroll the entry at Index of
+// Entries() into the viewport, see [BufferedBlock.ScrollToEntry].
+type ScrollToEntryMsg struct {
+	Index int
+}
+
+// HighlightMatchMsg tells BufferedBlock to highlight a match and scroll it into
+// the viewport, see [BufferedBlock.HighlightMatch]. Width <= 0 clears the
+// highlight.
+type HighlightMatchMsg struct {
+	Index int
+	Col   int
+	Width int
+}
+
+// entryMatch is the highlighted span of an entry
+type entryMatch struct {
+	idx        int
+	start, end int
+}
+
+// SetMatchStyle changes the style of the match highlighted by
+// [BufferedBlock.HighlightMatch]. Default to reverse video.
+func (c *BufferedBlock) SetMatchStyle(s tapioca.HighlightStyle) {
+	c.matchStyle = s
+}
+
+// HighlightMatch highlights width columns from col of the entry at entryIdx of
+// Entries(), and scrolls it into the viewport. Columns are counted like
+// [tapioca.Entry.Find], a wide rune is highlighted if any of its columns is
+// matched.
+//
+// The highlight is applied when rendering, stored entries are not modified.
+// Only one match is highlighted, it replaces previous one. Since the index
+// refers to current entries, you should highlight again after entries are
+// added or removed.
+//
+// You should use it only when you are handling an event message.
+func (c *BufferedBlock) HighlightMatch(entryIdx, col, width int) {
+	if width <= 0 || entryIdx < 0 || entryIdx >= c.entries.Size() {
+		c.ClearHighlight()
+		return
+	}
+
+	c.match = &entryMatch{idx: entryIdx, start: col, end: col + width}
+	c.ScrollToEntry(entryIdx)
+	if !c.hScroll {
+		return
+	}
+	// show the match, or its beginning if it is wider than the viewport
+	switch {
+	case col < c.X():
+		c.ScrollLeft(c.X() - col)
+	case col+width > c.X()+c.Width():
+		c.ScrollRight(min(col, col+width-c.Width()) - c.X())
+	}
+}
+
+// ClearHighlight removes the highlight set by HighlightMatch.
+//
+// You should use it only when you are handling an event message.
+func (c *BufferedBlock) ClearHighlight() {
+	c.match = nil
+}
+
+// ScrollToEntry scrolls vertically as little as possible so the entry at idx
+// of Entries() is shown in the viewport. If the entry is higher than the
+// viewport, its first line is shown at the top.
+//
+// It does nothing if the entry is filtered out or pinned.
+//
+// You should use it only when you are handling an event message.
+func (c *BufferedBlock) ScrollToEntry(idx int) {
+	line, h, ok := c.entryLine(idx)
+	if !ok {
+		return
+	}
+
+	rows := c.Height() - min(c.pin, c.Height())
+	switch {
+	case line < c.Y():
+		c.ScrollUp(c.Y() - line)
+	case line+h > c.Y()+rows:
+		c.ScrollDown(min(line, line+h-rows) - c.Y())
+	}
+}
+
+// entryLine returns the first line of the entry at idx on virtual screen and
+// how many lines it takes, false if it is filtered out or pinned
+func (c *BufferedBlock) entryLine(idx int) (line, h int, ok bool) {
+	all := c.entries.GetAll()
+	visible := c.visibleIndexes(all)
+	rows := min(c.pin, c.Height())
+	for _, i := range visible[:max(0, len(visible)-rows)] {
+		h = 1
+		if !c.hScroll && c.colSep == "" {
+			h = all[i].LinesWith(c.Width(), c.wrapPolicy)
+		}
+		if i == idx {
+			return line, h, true
+		}
+		line += h
+	}
+	return 0, 0, false
+}
+
+// highlightMatch replaces the matched entry in visible entries with a
+// highlighted copy
+func (c *BufferedBlock) highlightMatch(visible []*tapioca.Entry) {
+	if c.match == nil {
+		return
+	}
+
+	nth := 0
+	for i, e := range c.entries.All() {
+		if c.filter != nil && !c.filter(e) {
+			continue
+		}
+		if i == c.match.idx {
+			r := []tapioca.MatchRange{{Start: c.match.start, End: c.match.end}}
+			visible[nth] = e.Highlight(r, c.matchStyle)
+			return
+		}
+		nth++
+	}
+}
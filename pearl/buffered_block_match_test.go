@@ -0,0 +1,95 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponent_ScrollToEntry(t *testing.T) {
+	c := NewBufferedBlock(20, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 4, Height: 3})
+	for i := range 10 {
+		c.Append(fmt.Sprint(i))
+	}
+	c.Append("long line")
+
+	c.ScrollToEntry(7)
+	assert.Equal(t, 5, c.Y(), "should scroll down as little as possible")
+	c.ScrollToEntry(6)
+	assert.Equal(t, 5, c.Y(), "should not scroll if visible")
+	c.Update(ScrollToEntryMsg{Index: 2})
+	assert.Equal(t, 2, c.Y(), "should scroll up as little as possible")
+
+	// takes 3 lines
+	c.ScrollToEntry(10)
+	assert.Equal(t, 10, c.Y())
+	assert.Equal(t, "long\n lin\ne   ", c.View())
+
+	c.SetFilter(func(e *tapioca.Entry) bool { return e.String() != "3" })
+	c.ScrollToTop()
+	c.ScrollToEntry(3)
+	assert.Equal(t, 0, c.Y(), "filtered entry should be ignored")
+	c.ScrollToEntry(4)
+	assert.Equal(t, 1, c.Y(), "should count visible entries only")
+}
+
+func TestComponent_HighlightMatch(t *testing.T) {
+	c := NewBufferedBlock(20, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 5, Height: 2})
+	for _, s := range []string{"abc", "a中b", "xyz", "\x1b[31mred\x1b[0m"} {
+		c.Append(s)
+	}
+
+	c.HighlightMatch(1, 2, 1)
+	assert.Equal(t, 0, c.Y())
+	assert.Equal(t, "abc  \na\x1b[7m中\x1b[0mb ", c.View(), "wide rune should be highlighted as a whole")
+	assert.Equal(t, "a中b", c.Entries()[1].StyledString(), "stored entry should not change")
+
+	c.Update(HighlightMatchMsg{Index: 3, Col: 1, Width: 1})
+	assert.Equal(t, 2, c.Y(), "should scroll to the match")
+	assert.Equal(t, "xyz  \n\x1b[31mr\x1b[7me\x1b[27md\x1b[0m  ", c.View(), "only one match is highlighted")
+
+	c.SetMatchStyle(tapioca.HighlightStyle{Bg: "43"})
+	assert.Contains(t, c.View(), "\x1b[43me")
+
+	c.HighlightMatch(3, 0, 0)
+	assert.Equal(t, "xyz  \n\x1b[31mred\x1b[0m  ", c.View(), "should clear by zero width")
+
+	c.HighlightMatch(0, 0, 1)
+	c.Clear()
+	c.Append("abc")
+	assert.NotContains(t, c.View(), "\x1b[", "should clear with entries")
+}
+
+func TestComponent_HighlightMatchHScroll(t *testing.T) {
+	c := NewBufferedBlock(20, true, true)
+	c.Update(tapioca.ResizeMsg{Width: 5, Height: 1})
+	c.Append(strings.Repeat("0123456789", 3))
+
+	c.HighlightMatch(0, 15, 3)
+	assert.Equal(t, 13, c.X(), "should scroll right to show whole match")
+	assert.Equal(t, "34\x1b[7m567\x1b[0m", c.View())
+
+	c.HighlightMatch(0, 2, 2)
+	assert.Equal(t, 2, c.X(), "should scroll left to the match")
+
+	c.HighlightMatch(0, 20, 8)
+	assert.Equal(t, 20, c.X(), "should show beginning of wide match")
+}
+
+func TestLogPanel_HighlightMatch(t *testing.T) {
+	lp := NewLogPanel(10)
+	lp.Update(tapioca.ResizeMsg{Width: 3, Height: 1})
+	lp.Update(LogMsg("foo\nbar\nbaz"))
+
+	lp.Update(HighlightMatchMsg{Index: 0, Col: 1, Width: 1})
+	assert.Equal(t, "f\x1b[7mo\x1b[0mo", lp.View())
+}
@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strconv"
+
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// glyphs of minimap, from sparse to dense
+var minimapGlyphs = []rune(" ░▒▓█")
+
+// SetMinimap shows or hides a minimap at right side of the component, which
+// takes 1 column from content.
+//
+// Entries are divided into bands, one band per row. Each row shows how dense
+// the band is, which is computed by average width of entries in it. Bands in
+// the viewport are highlighted in reverse video.
+//
+// Minimap is not shown if the component is only 1 column wide.
+func (c *BufferedBlock) SetMinimap(enable bool) {
+	if c.minimap == enable {
+		return
+	}
+	c.minimap = enable
+	if c.fullWidth > 0 {
+		c.resize(c.fullWidth, c.Height())
+	}
+}
+
+// resize resizes the viewport, leaving space for line numbers, minimap and
+// scrollbar
+func (c *BufferedBlock) resize(w, h int) {
+	c.fullWidth = w
+	c.HandleEvent(tapioca.ResizeMsg{Width: c.layoutWidth(), Height: h})
+	c.recomputeCachedInfo()
+}
+
+// layoutWidth computes width of gutter, and returns the width of content
+func (c *BufferedBlock) layoutWidth() int {
+	w := c.fullWidth
+	if c.showScrollbar() {
+		w--
+	}
+	if c.minimap && w > 1 {
+		w--
+	}
+
+	c.gutter = 0
+	if c.numbers {
+		if g := len(strconv.Itoa(c.entries.Size())) + 1; w > g {
+			c.gutter = g
+			w -= g
+		}
+	}
+	return w
+}
+
+func (c *BufferedBlock) showMinimap() bool {
+	w := c.fullWidth
+	if c.showScrollbar() {
+		w--
+	}
+	return c.minimap && w > c.Width()+c.gutter
+}
+
+// viewportEntries returns the range of visible entries shown in the viewport,
+// last is inclusive. first > last if no entry is shown.
+func (c *BufferedBlock) viewportEntries(entries []*tapioca.Entry) (first, last int) {
+	history, _, rows := c.splitPinned(entries)
+	height := c.Height() - rows
+	first, last = len(history), len(history)-1
+
+	top, bottom := c.Y(), c.Y()+height // lines of virtual screen
+	cur := 0
+	for idx, e := range history {
+		if cur >= bottom {
+			break
+		}
+		h := 1
+		if !c.hScroll && c.colSep == "" {
+			h = e.LinesWith(c.Width(), c.wrapPolicy)
+		}
+		if cur+h > top {
+			first = min(first, idx)
+			last = idx
+		}
+		cur += h
+	}
+
+	if rows > 0 {
+		// pinned entries are always shown
+		if first > last {
+			first = len(history)
+		}
+		last = len(entries) - 1
+	}
+	return
+}
+
+// appendMinimap appends a minimap column to lines
+func (c *BufferedBlock) appendMinimap(lines []string, entries []*tapioca.Entry) {
+	n, rows := len(entries), len(lines)
+	first, last := c.viewportEntries(entries)
+	levels := len(minimapGlyphs) - 1
+
+	for i := range lines {
+		lo, hi := i, min(i+1, n)
+		if n > rows {
+			lo, hi = i*n/rows, (i+1)*n/rows
+		}
+
+		glyph := string(tapioca.FillRune)
+		if lo < hi {
+			total := 0
+			for _, e := range entries[lo:hi] {
+				total += min(e.Width(), c.Width())
+			}
+			// round up, so band with any content is never blank
+			level := (total*levels + (hi-lo)*c.Width() - 1) / ((hi - lo) * c.Width())
+			glyph = string(minimapGlyphs[min(level, levels)])
+		}
+		if lo < hi && lo <= last && hi-1 >= first {
+			glyph = "\x1b[7m" + glyph + "\x1b[27m"
+		}
+		lines[i] += glyph
+	}
+}
@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"testing"
+
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponent_SetMinimap(t *testing.T) {
+	rev := func(s string) string { return "\x1b[7m" + s + "\x1b[27m" }
+
+	c := NewBufferedBlock(100, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 5, Height: 4})
+	for _, s := range []string{"aaaa", "aaaa", "aa", "aa", "a", "", "", ""} {
+		c.Append(s)
+	}
+	c.SetMinimap(true)
+	assert.Equal(t, 4, c.Width(), "minimap takes 1 column")
+
+	assert.Equal(t, "aaaa"+rev("█")+"\n"+
+		"aaaa"+rev("▒")+"\n"+
+		"aa  ░\n"+
+		"aa   ", c.View())
+
+	c.ScrollToBottom()
+	assert.Equal(t, "a   █\n"+
+		"    ▒\n"+
+		"    "+rev("░")+"\n"+
+		"    "+rev(" "), c.View())
+
+	c.SetMinimap(false)
+	assert.Equal(t, 5, c.Width())
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  5,
+		Height: 4,
+		Model:  c,
+	}))
+
+	t.Run("fewer entries than rows", func(t *testing.T) {
+		c := NewBufferedBlock(100, false, true)
+		c.SetMinimap(true)
+		c.Update(tapioca.ResizeMsg{Width: 3, Height: 3})
+		c.Append("ab")
+		assert.Equal(t, "ab"+rev("█")+"\n   \n   ", c.View())
+	})
+}
@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// SetLineNumbers shows or hides entry numbers in a gutter at left side of the
+// component, which takes columns from content.
+//
+// Numbers track entries, not display rows: the number is the position of the
+// entry in Entries(), starting from 1, and is shown at the first row of the
+// entry. Rows wrapped from an entry have blank gutter. The gutter grows with
+// the number of entries.
+//
+// Gutter is not shown if the component is too narrow.
+func (c *BufferedBlock) SetLineNumbers(enable bool) {
+	if c.numbers == enable {
+		return
+	}
+	c.numbers = enable
+	if c.fullWidth > 0 {
+		c.resize(c.fullWidth, c.Height())
+	}
+}
+
+// visibleIndexes returns indexes of entries passing the filter
+func (c *BufferedBlock) visibleIndexes(all []*tapioca.Entry) []int {
+	ret := make([]int, 0, len(all))
+	for idx, e := range all {
+		if c.filter == nil || c.filter(e) {
+			ret = append(ret, idx)
+		}
+	}
+	return ret
+}
+
+// rowEntry describes which entry is displayed in a row of the viewport
+type rowEntry struct {
+	// index in Entries(), -1 if the row is blank
+	idx int
+	// position in visible entries
+	nth int
+	// line of virtual screen
+	line int
+	// whether it is the first row of the entry
+	first bool
+}
+
+// rowEntries returns the entry displayed in each row of the viewport
+func (c *BufferedBlock) rowEntries() []rowEntry {
+	all := c.entries.GetAll()
+	visible := c.visibleIndexes(all)
+	rows := min(c.pin, c.Height())
+	history := visible[:max(0, len(visible)-rows)]
+	pinned := visible[len(history):]
+	bottom := c.Y() + c.Height() - rows // lines of virtual screen
+
+	ret := make([]rowEntry, c.Height())
+	for i := range ret {
+		ret[i] = rowEntry{idx: -1, line: c.Y() + i}
+	}
+	line := 0
+	for nth, idx := range history {
+		if line >= bottom {
+			break
+		}
+		h := 1
+		if !c.hScroll && c.colSep == "" {
+			h = all[idx].LinesWith(c.Width(), c.wrapPolicy)
+		}
+		for l := max(line, c.Y()); l < min(line+h, bottom); l++ {
+			ret[l-c.Y()] = rowEntry{idx: idx, nth: nth, line: l, first: l == line}
+		}
+		line += h
+	}
+
+	// pinned entries are aligned to the bottom
+	for i, idx := range pinned {
+		row := c.Height() - len(pinned) + i
+		ret[row] = rowEntry{idx: idx, nth: len(history) + i, line: c.Y() + row, first: true}
+	}
+	return ret
+}
+
+// rowNumbers returns entry number for each row of the viewport, 0 if the row
+// is blank or not the first row of an entry
+func (c *BufferedBlock) rowNumbers() []int {
+	ret := make([]int, c.Height())
+	for i, r := range c.rowEntries() {
+		if r.first {
+			ret[i] = r.idx + 1
+		}
+	}
+	return ret
+}
+
+// prependGutter adds entry numbers to lines
+func (c *BufferedBlock) prependGutter(lines []string) {
+	blank := strings.Repeat(" ", c.gutter)
+	for i, n := range c.rowNumbers() {
+		if n == 0 {
+			lines[i] = blank + lines[i]
+			continue
+		}
+		lines[i] = fmt.Sprintf("%*d ", c.gutter-1, n) + lines[i]
+	}
+}
@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+// glyphs of scrollbar
+const (
+	scrollbarTrack = "│"
+	scrollbarThumb = "█"
+)
+
+// SetScrollbar shows or hides a vertical scrollbar at rightmost column of the
+// component, which takes 1 column from content. The thumb shows the size and
+// position of the viewport in all lines, it fills the track if there's nothing
+// to scroll.
+//
+// Scrollbar is shown only if vertical scroll is enabled, and the component is
+// wider than 1 column. It is placed at right side of the minimap.
+func (c *BufferedBlock) SetScrollbar(enable bool) {
+	if c.scrollbar == enable {
+		return
+	}
+	c.scrollbar = enable
+	if c.fullWidth > 0 {
+		c.resize(c.fullWidth, c.Height())
+	}
+}
+
+func (c *BufferedBlock) showScrollbar() bool {
+	return c.scrollbar && c.vScroll && c.fullWidth > 1
+}
+
+// appendScrollbar appends a scrollbar column to lines
+func (c *BufferedBlock) appendScrollbar(lines []string) {
+	rows, total := len(lines), c.ExtentV()
+
+	size, pos := rows, 0
+	if total > c.Height() {
+		size = max(1, rows*c.Height()/total)
+		pos = int(c.ScrollPercentV()*float64(rows-size) + 0.5)
+	}
+
+	for i := range lines {
+		if i >= pos && i < pos+size {
+			lines[i] += scrollbarThumb
+			continue
+		}
+		lines[i] += "\x1b[2m" + scrollbarTrack + "\x1b[22m"
+	}
+}
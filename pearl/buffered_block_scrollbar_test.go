@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponent_SetScrollbar(t *testing.T) {
+	track := "\x1b[2m│\x1b[22m"
+
+	c := NewBufferedBlock(100, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 4, Height: 4})
+	for i := range 8 {
+		c.Append(strconv.Itoa(i))
+	}
+	c.SetScrollbar(true)
+	assert.Equal(t, 3, c.Width(), "scrollbar takes 1 column")
+
+	assert.Equal(t, ""+
+		"0  █\n"+
+		"1  █\n"+
+		"2  "+track+"\n"+
+		"3  "+track, c.View())
+
+	c.ScrollDown(2)
+	assert.Equal(t, ""+
+		"2  "+track+"\n"+
+		"3  █\n"+
+		"4  █\n"+
+		"5  "+track, c.View())
+
+	c.ScrollToBottom()
+	assert.Equal(t, ""+
+		"4  "+track+"\n"+
+		"5  "+track+"\n"+
+		"6  █\n"+
+		"7  █", c.View())
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  4,
+		Height: 4,
+		Model:  c,
+	}))
+
+	c.SetScrollbar(false)
+	assert.Equal(t, 4, c.Width())
+
+	t.Run("nothing to scroll", func(t *testing.T) {
+		c := NewBufferedBlock(100, false, true)
+		c.SetScrollbar(true)
+		c.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+		c.Append("ab")
+		assert.Equal(t, "ab█\n  █", c.View())
+	})
+
+	t.Run("with minimap", func(t *testing.T) {
+		c := NewBufferedBlock(100, false, true)
+		c.SetScrollbar(true)
+		c.SetMinimap(true)
+		c.Update(tapioca.ResizeMsg{Width: 3, Height: 1})
+		c.Append("a")
+		assert.Equal(t, "a\x1b[7m█\x1b[27m█", c.View())
+	})
+
+	t.Run("too narrow", func(t *testing.T) {
+		c := NewBufferedBlock(100, false, true)
+		c.SetScrollbar(true)
+		c.Update(tapioca.ResizeMsg{Width: 1, Height: 2})
+		c.Append("a")
+		assert.Equal(t, "a\n ", c.View())
+	})
+
+	t.Run("vertical scroll disabled", func(t *testing.T) {
+		c := NewBufferedBlock(100, false, true)
+		c.SetScrollbar(true)
+		c.Update(tapioca.ResizeMsg{Width: 3, Height: 1})
+		assert.Equal(t, 2, c.Width())
+		c.SetVerticalScrollable(false)
+		assert.Equal(t, 3, c.Width())
+	})
+}
@@ -5,6 +5,7 @@
 package pearl
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 
@@ -337,3 +338,541 @@ func TestComponent_EdgeCase(t *testing.T) {
 		assert.Equal(t, "One\nTwo\n   ", comp.View())
 	})
 }
+
+func TestComponent_WrapPolicy(t *testing.T) {
+	t.Run("break word", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.SetWrapPolicy(tapioca.BreakWord)
+		comp.Append("hello world")
+		comp.Update(tapioca.ResizeMsg{Width: 8, Height: 3})
+		assert.Equal(t, "hello   \nworld   \n        ", comp.View())
+	})
+
+	t.Run("overflow with horizontal scroll", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.SetWrapPolicy(tapioca.Overflow)
+		comp.Append("go https://example.com")
+		comp.Update(tapioca.ResizeMsg{Width: 8, Height: 2})
+		assert.Equal(t, "go      \nhttps://", comp.View())
+
+		comp.Update(tapioca.ScrollEndMsg{})
+		assert.Equal(t, "        \nmple.com", comp.View())
+
+		assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  8,
+			Height: 2,
+			Model:  comp,
+		}))
+	})
+}
+
+func TestComponent_WindowSizeMsg(t *testing.T) {
+	comp := NewBufferedBlock(10, false, true)
+	comp.Append("hello")
+	comp.Update(tea.WindowSizeMsg{Width: 7, Height: 2})
+	assert.Equal(t, "hello  \n       ", comp.View())
+}
+
+func TestComponent_EntryIndexAtRow(t *testing.T) {
+	type result struct {
+		idx int
+		ok  bool
+	}
+	at := func(c *BufferedBlock, row int) result {
+		idx, ok := c.EntryIndexAtRow(row)
+		return result{idx, ok}
+	}
+
+	t.Run("wrap", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.Append("One")
+		comp.Append("TwoTwoTwo") // 2 lines
+		comp.Append("Three")
+		comp.Update(tapioca.ResizeMsg{Width: 5, Height: 5})
+
+		assert.Equal(t, result{0, true}, at(comp, 0))
+		assert.Equal(t, result{1, true}, at(comp, 1))
+		assert.Equal(t, result{1, true}, at(comp, 2))
+		assert.Equal(t, result{2, true}, at(comp, 3))
+		assert.Equal(t, result{0, false}, at(comp, 4), "blank row")
+		assert.Equal(t, result{0, false}, at(comp, 5), "out of viewport")
+		assert.Equal(t, result{0, false}, at(comp, -1), "out of viewport")
+	})
+
+	t.Run("wrap with scroll", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.Append("One")
+		comp.Append("TwoTwoTwo") // 2 lines
+		comp.Append("Three")
+		comp.Update(tapioca.ResizeMsg{Width: 5, Height: 2})
+		comp.Update(tapioca.ScrollDownMsg(2))
+
+		assert.Equal(t, result{1, true}, at(comp, 0))
+		assert.Equal(t, result{2, true}, at(comp, 1))
+	})
+
+	t.Run("no wrap with scroll", func(t *testing.T) {
+		comp := NewBufferedBlock(10, true, true)
+		comp.Append("One")
+		comp.Append("TwoTwoTwo")
+		comp.Append("Three")
+		comp.Update(tapioca.ResizeMsg{Width: 5, Height: 2})
+		comp.Update(tapioca.ScrollDownMsg(1))
+
+		assert.Equal(t, result{1, true}, at(comp, 0))
+		assert.Equal(t, result{2, true}, at(comp, 1))
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.Append("One")
+		comp.Append("Two")
+		comp.Append("Three")
+		comp.SetFilter(func(e *tapioca.Entry) bool { return e.String() != "Two" })
+		comp.Update(tapioca.ResizeMsg{Width: 5, Height: 3})
+
+		assert.Equal(t, result{0, true}, at(comp, 0))
+		assert.Equal(t, result{2, true}, at(comp, 1))
+		assert.Equal(t, result{0, false}, at(comp, 2))
+	})
+}
+
+func TestComponent_PinNewest(t *testing.T) {
+	newComp := func() *BufferedBlock {
+		comp := NewBufferedBlock(10, false, true)
+		comp.PinNewest(2)
+		for _, s := range []string{"a", "b", "c", "d", "e", "f"} {
+			comp.Append(s)
+		}
+		comp.Update(tapioca.ResizeMsg{Width: 3, Height: 4})
+		return comp
+	}
+
+	t.Run("top", func(t *testing.T) {
+		comp := newComp()
+		assert.Equal(t, "a  \nb  \ne  \nf  ", comp.View())
+	})
+
+	t.Run("scroll history", func(t *testing.T) {
+		comp := newComp()
+		comp.Update(tapioca.ScrollDownMsg(1))
+		assert.Equal(t, "b  \nc  \ne  \nf  ", comp.View())
+
+		// d is the last history entry, it stays above pinned ones
+		comp.Update(tapioca.ScrollBottomMsg{})
+		assert.Equal(t, "c  \nd  \ne  \nf  ", comp.View())
+		comp.Update(tapioca.ScrollDownMsg(1))
+		assert.Equal(t, "c  \nd  \ne  \nf  ", comp.View())
+	})
+
+	t.Run("boundary", func(t *testing.T) {
+		comp := newComp()
+		comp.Update(tapioca.ScrollBottomMsg{})
+		for row, expect := range []int{2, 3, 4, 5} {
+			idx, ok := comp.EntryIndexAtRow(row)
+			assert.True(t, ok)
+			assert.Equal(t, expect, idx, "row %d", row)
+		}
+
+		comp.Append("g")
+		assert.Equal(t, "c  \nd  \nf  \ng  ", comp.View())
+	})
+
+	t.Run("history shorter than viewport", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.PinNewest(1)
+		for _, s := range []string{"a", "b", "c"} {
+			comp.Append(s)
+		}
+		comp.Update(tapioca.ResizeMsg{Width: 4, Height: 5})
+		comp.Update(tapioca.ScrollBottomMsg{})
+		assert.Equal(t, 0, comp.Y())
+		assert.Equal(t, 5, comp.ExtentV())
+		assert.Equal(t, "a   \nb   \n    \n    \nc   ", comp.View())
+	})
+
+	t.Run("history fills viewport exactly", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.PinNewest(2)
+		for _, s := range []string{"a", "b", "c", "d"} {
+			comp.Append(s)
+		}
+		comp.Update(tapioca.ResizeMsg{Width: 2, Height: 4})
+		comp.Update(tapioca.ScrollBottomMsg{})
+		assert.Equal(t, 0, comp.Y())
+		assert.Equal(t, "a \nb \nc \nd ", comp.View())
+
+		// one more history entry makes it scrollable by one line
+		comp.Append("e")
+		comp.Update(tapioca.ScrollBottomMsg{})
+		assert.Equal(t, 1, comp.Y())
+		assert.Equal(t, "b \nc \nd \ne ", comp.View())
+	})
+
+	t.Run("fewer entries than pinned rows", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.PinNewest(3)
+		comp.Append("a")
+		comp.Update(tapioca.ResizeMsg{Width: 3, Height: 4})
+		assert.Equal(t, "   \n   \n   \na  ", comp.View())
+
+		_, ok := comp.EntryIndexAtRow(2)
+		assert.False(t, ok)
+		idx, ok := comp.EntryIndexAtRow(3)
+		assert.True(t, ok)
+		assert.Equal(t, 0, idx)
+	})
+
+	t.Run("pinned entries are not wrapped", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.PinNewest(1)
+		comp.Append("hello world")
+		comp.Append("newest entry")
+		comp.Update(tapioca.ResizeMsg{Width: 5, Height: 3})
+		assert.Equal(t, "hello\n worl\nnewes", comp.View())
+
+		assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  5,
+			Height: 3,
+			Model:  comp,
+		}))
+	})
+}
+
+func TestComponent_Columnize(t *testing.T) {
+	newComp := func() *BufferedBlock {
+		comp := NewBufferedBlock(10, false, true)
+		comp.SetColumnize(" ")
+		comp.Append("PID  CMD    ARGS")
+		comp.Append("1 init")
+		comp.Append("1234\tsshd   -D")
+		comp.Append("42 bash --login --norc")
+		comp.Update(tapioca.ResizeMsg{Width: 16, Height: 3})
+		return comp
+	}
+
+	t.Run("aligned", func(t *testing.T) {
+		comp := newComp()
+		assert.Equal(t, ""+
+			"PID  CMD  ARGS  \n"+
+			"1    init       \n"+
+			"1234 sshd -D    ",
+			comp.View())
+	})
+
+	t.Run("widths follow viewport", func(t *testing.T) {
+		comp := newComp()
+		comp.Update(tapioca.ScrollDownMsg(1))
+		assert.Equal(t, ""+
+			"1    init       \n"+
+			"1234 sshd -D    \n"+
+			"42   bash --logi",
+			comp.View())
+	})
+
+	t.Run("separator", func(t *testing.T) {
+		comp := newComp()
+		comp.SetColumnize(" | ")
+		assert.Equal(t, ""+
+			"PID  | CMD  | AR\n"+
+			"1    | init     \n"+
+			"1234 | sshd | -D",
+			comp.View())
+
+		comp.SetColumnize("")
+		assert.Equal(t, "PID  CMD    ARGS", strings.Split(comp.View(), "\n")[0])
+	})
+
+	t.Run("styled", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.SetColumnize(" ")
+		comp.Append("\x1b[31mab\x1b[0m c")
+		comp.Append("d e")
+		comp.Update(tapioca.ResizeMsg{Width: 5, Height: 2})
+		assert.Equal(t, "\x1b[31mab\x1b[0m c \nd  e ", comp.View())
+	})
+}
+
+func TestComponent_SetLineWrap(t *testing.T) {
+	comp := NewBufferedBlock(10, false, true)
+	comp.Append("abcdefgh")
+	comp.Append("ij")
+	comp.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	comp.Update(tapioca.ScrollBottomMsg{})
+	assert.True(t, comp.LineWrap())
+	assert.Equal(t, "efgh\nij  ", comp.View())
+
+	comp.SetLineWrap(false)
+	assert.False(t, comp.LineWrap())
+	assert.Equal(t, 0, comp.Y(), "scroll position should be clamped")
+	assert.Equal(t, "abcd\nij  ", comp.View())
+	comp.Update(tapioca.ScrollEndMsg{})
+	assert.Equal(t, "efgh\n    ", comp.View())
+
+	comp.SetLineWrap(true)
+	assert.Equal(t, 0, comp.X())
+	assert.Equal(t, "abcd\nefgh", comp.View())
+}
+
+func TestComponent_SetScrollable(t *testing.T) {
+	t.Run("horizontal", func(t *testing.T) {
+		comp := NewBufferedBlock(10, true, true)
+		comp.Append("abcdefgh")
+		comp.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+		comp.Update(tapioca.ScrollEndMsg{})
+		assert.Equal(t, 4, comp.X())
+
+		comp.SetHorizontalScrollable(false)
+		assert.Equal(t, 0, comp.X())
+		assert.Equal(t, "abcd\nefgh", comp.View())
+		comp.Update(tapioca.ScrollEndMsg{})
+		assert.Equal(t, 0, comp.X(), "cannot scroll horizontally with line wrap")
+
+		comp.SetHorizontalScrollable(true)
+		assert.Equal(t, "abcd\n    ", comp.View())
+		comp.Update(tapioca.ScrollEndMsg{})
+		assert.Equal(t, 4, comp.X())
+	})
+
+	t.Run("vertical", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.Append("a")
+		comp.Append("b")
+		comp.Append("c")
+		comp.Update(tapioca.ResizeMsg{Width: 2, Height: 2})
+		comp.Update(tapioca.ScrollBottomMsg{})
+		assert.Equal(t, 1, comp.Y())
+
+		comp.SetVerticalScrollable(false)
+		assert.Equal(t, 0, comp.Y())
+		assert.Equal(t, "a \nb ", comp.View())
+	})
+}
+
+func TestComponent_SetBidi(t *testing.T) {
+	t.Run("no wrap", func(t *testing.T) {
+		comp := NewBufferedBlock(10, true, true)
+		comp.Append("hello")
+		comp.Append("שלום 12")
+		comp.Append("אבגדהוזחטי")
+		comp.Update(tapioca.ResizeMsg{Width: 8, Height: 3})
+		assert.Equal(t, "hello   \nשלום 12 \nאבגדהוזח", comp.View(), "disabled by default")
+
+		comp.SetBidi(true)
+		// start of RTL line is at right edge
+		assert.Equal(t, "hello   \n 12 םולש\nחזוהדגבא", comp.View())
+
+		comp.Update(tapioca.ScrollRightMsg(2))
+		assert.Equal(t, "llo     \n   12 םו\nיטחזוהדג", comp.View())
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		comp := NewBufferedBlock(10, false, true)
+		comp.SetBidi(true)
+		comp.SetWrapPolicy(tapioca.BreakWord)
+		comp.Append("שלום עולם")
+		comp.Update(tapioca.ResizeMsg{Width: 6, Height: 2})
+		assert.Equal(t, "  םולש\n  םלוע", comp.View())
+
+		assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  6,
+			Height: 2,
+			Model:  comp,
+		}))
+	})
+}
+
+func TestComponent_AppendReport(t *testing.T) {
+	c := NewBufferedBlock(2, false, true)
+	assert.False(t, c.AppendReport("a"))
+	assert.False(t, c.AppendReport("b"))
+	assert.True(t, c.AppendReport("c"))
+	assert.True(t, c.AppendReport("d"))
+
+	entries := c.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "c", entries[0].String())
+	assert.Equal(t, "d", entries[1].String())
+}
+
+func TestComponent_ResizeBuffer(t *testing.T) {
+	c := NewBufferedBlock(5, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		c.Append(s)
+	}
+
+	c.ResizeBuffer(2)
+	assert.Equal(t, 2, c.Capacity())
+	entries := c.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "d", entries[0].String(), "should keep newest entries")
+	assert.Equal(t, "e", entries[1].String())
+	assert.Equal(t, 2, c.ExtentV())
+}
+
+func TestComponent_SetLineNumbers(t *testing.T) {
+	c := NewBufferedBlock(100, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 6, Height: 3})
+	c.SetLineNumbers(true)
+	c.Append("abcdefg")
+	c.Append("\x1b[31mx\x1b[0m")
+
+	assert.Equal(t, 4, c.Width())
+	assert.Equal(t, "1 abcd\n  efg \n2 \x1b[31mx\x1b[0m   ", c.View())
+
+	// gutter grows with number of entries
+	for i := 3; i <= 10; i++ {
+		c.Append(strconv.Itoa(i))
+	}
+	assert.Equal(t, 3, c.Width())
+	c.ScrollToBottom()
+	assert.Equal(t, " 8 8  \n 9 9  \n10 10 ", c.View())
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  6,
+		Height: 3,
+		Model:  c,
+	}))
+
+	t.Run("pinned", func(t *testing.T) {
+		c := NewBufferedBlock(100, true, true)
+		c.Update(tapioca.ResizeMsg{Width: 4, Height: 3})
+		c.SetLineNumbers(true)
+		c.PinNewest(1)
+		c.Append("a")
+		c.Append("b")
+		assert.Equal(t, "1 a \n    \n2 b ", c.View())
+	})
+
+	c.SetLineNumbers(false)
+	assert.Equal(t, 6, c.Width())
+}
+
+func TestComponent_SetZebraStyle(t *testing.T) {
+	const bg = "\x1b[48;5;236m"
+	newBlock := func() *BufferedBlock {
+		c := NewBufferedBlock(100, false, true)
+		c.Update(tapioca.ResizeMsg{Width: 3, Height: 4})
+		c.Append("abcd")
+		c.Append("\x1b[31mx\x1b[0m")
+		return c
+	}
+
+	t.Run("by row", func(t *testing.T) {
+		c := newBlock()
+		c.SetZebraStyle(ZebraStyle{Background: bg})
+		assert.Equal(t, "abc\n"+bg+"d  \x1b[0m\n\x1b[31mx\x1b[0m  \n   ", c.View())
+
+		c.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+		c.ScrollDown(1)
+		assert.Equal(t, bg+"d  \x1b[0m\n\x1b[31mx\x1b[0m  ", c.View())
+	})
+
+	t.Run("by entry", func(t *testing.T) {
+		c := newBlock()
+		c.SetZebraStyle(ZebraStyle{Background: bg, ByEntry: true})
+		assert.Equal(t, "abc\nd  \n"+bg+"\x1b[31mx\x1b[0m"+bg+"  \x1b[0m\n   ", c.View())
+		assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  3,
+			Height: 4,
+			Model:  c,
+		}))
+	})
+
+	t.Run("background off", func(t *testing.T) {
+		c := NewBufferedBlock(100, false, true)
+		c.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+		c.SetZebraStyle(ZebraStyle{Background: bg})
+		c.Append("a")
+		c.Append("\x1b[1;41mx\x1b[49my\x1b[0m")
+		assert.Equal(t, "a  \n"+bg+"\x1b[41m\x1b[1mx\x1b[49m"+bg+"y\x1b[0m"+bg+" \x1b[0m", c.View())
+	})
+}
+
+func TestComponent_SetEmptyPlaceholder(t *testing.T) {
+	c := NewBufferedBlock(100, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 9, Height: 3})
+	c.SetEmptyPlaceholder("empty")
+	assert.Equal(t, "         \n  \x1b[2mempty\x1b[0m  \n         ", c.View())
+
+	// truncated when too narrow
+	c.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	assert.Equal(t, "\x1b[2memp\x1b[0m…\n    ", c.View())
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  4,
+		Height: 2,
+		Model:  c,
+	}))
+
+	// not shown when entries are filtered out
+	c.Append("abc")
+	assert.Equal(t, "abc \n    ", c.View())
+	c.SetFilter(func(*tapioca.Entry) bool { return false })
+	assert.Equal(t, "    \n    ", c.View())
+
+	c.Clear()
+	c.SetEmptyPlaceholder("")
+	assert.Equal(t, "    \n    ", c.View())
+}
+
+func TestComponent_ClampAfterClear(t *testing.T) {
+	c := NewBufferedBlock(10, true, true)
+	c.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+	for _, s := range []string{"0123456", "a", "b", "c", "d"} {
+		c.Append(s)
+	}
+	c.ScrollTo(4, 3)
+	assert.Equal(t, 4, c.X())
+	assert.Equal(t, 3, c.Y())
+
+	c.Clear()
+	assert.Equal(t, 0, c.X())
+	assert.Equal(t, 0, c.Y())
+
+	c.Append("x")
+	assert.Equal(t, "x  \n   ", c.View(), "should not show blank viewport")
+}
+
+func TestComponent_ScrollPage(t *testing.T) {
+	c := NewBufferedBlock(20, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 2, Height: 3})
+	for i := range 10 {
+		c.Append(strconv.Itoa(i))
+	}
+
+	c.Update(tapioca.ScrollPageDownMsg{})
+	assert.Equal(t, "2 \n3 \n4 ", c.View(), "last row of previous page is kept")
+	c.Update(tapioca.ScrollPageDownMsg{})
+	c.Update(tapioca.ScrollPageDownMsg{})
+	c.Update(tapioca.ScrollPageDownMsg{})
+	assert.Equal(t, "7 \n8 \n9 ", c.View())
+	c.Update(tapioca.ScrollPageUpMsg{})
+	assert.Equal(t, "5 \n6 \n7 ", c.View())
+}
+
+func TestComponent_SetFollow(t *testing.T) {
+	c := NewBufferedBlock(10, false, true)
+	c.Update(tapioca.ResizeMsg{Width: 2, Height: 2})
+	c.Append("a")
+	c.Append("b")
+	c.Append("c")
+	assert.Equal(t, "a \nb ", c.View(), "not following by default")
+
+	c.SetFollow(true)
+	assert.True(t, c.Follow())
+	c.ScrollToBottom()
+	c.Append("d")
+	assert.Equal(t, "c \nd ", c.View())
+	assert.False(t, c.AppendReport("e"))
+	assert.Equal(t, "d \ne ", c.View())
+
+	// stops following once scrolled up
+	c.ScrollUp(1)
+	c.AppendEntry(tapioca.NewEntry("f"))
+	assert.Equal(t, "c \nd ", c.View())
+
+	// and follows again at bottom
+	c.ScrollToBottom()
+	c.Append("g")
+	assert.Equal(t, "f \ng ", c.View())
+}
@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// BusyIndicator shows an animated spinner followed by a label, like
+// "⣾ working…", for operations without granular progress. It is rendered in
+// the first row, and is blank when idle.
+//
+// It is idle when created, use [BusyIndicator.SetActive] or the function
+// returned by [BusyIndicator.Setter] to start it.
+//
+// You must create BusyIndicator with Busy().
+type BusyIndicator struct {
+	// Label is shown after the spinner.
+	Label string
+
+	id      int64
+	active  bool
+	spinner spinner.Model
+	w, h    int
+
+	// if not nil, spinner is driven by it instead of its own ticks
+	anim       *tapioca.Animator
+	cancelAnim func()
+}
+
+// BusySetMsg is a message to activate or deactivate a BusyIndicator.
+type BusySetMsg struct {
+	id     int64
+	active bool
+}
+
+// Busy creates an idle BusyIndicator showing label.
+func Busy(label string) *BusyIndicator {
+	return &BusyIndicator{
+		Label:   label,
+		id:      tapioca.NewID(),
+		spinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
+	}
+}
+
+// SetAnimator makes the spinner driven by a, instead of its own timer. It
+// should be called before the indicator is activated.
+func (b *BusyIndicator) SetAnimator(a *tapioca.Animator) {
+	b.anim = a
+}
+
+// Active reports whether the indicator is animating.
+func (b *BusyIndicator) Active() bool { return b.active }
+
+// SetActive starts or stops the indicator. It returns the command to start
+// the animation, which is nil if nothing is changed.
+//
+// You should use it only when you are handling an event message.
+func (b *BusyIndicator) SetActive(active bool) tea.Cmd {
+	if b.active == active {
+		return nil
+	}
+	b.active = active
+
+	if b.anim == nil {
+		if active {
+			return b.spinner.Tick
+		}
+		return nil
+	}
+
+	if active {
+		var cmd tea.Cmd
+		b.cancelAnim, cmd = b.anim.Subscribe(b.onFrame)
+		return cmd
+	}
+	b.cancelAnim()
+	b.cancelAnim = nil
+	return nil
+}
+
+func (b *BusyIndicator) onFrame(now time.Time) {
+	// TickMsg without ID is accepted by every spinner, the command for next
+	// tick is dropped as the animator handles it
+	b.spinner, _ = b.spinner.Update(spinner.TickMsg{Time: now})
+}
+
+// Setter returns a function that sends a BusySetMsg to start or stop the
+// indicator.
+func (b *BusyIndicator) Setter(send func(tea.Msg)) func(active bool) {
+	return func(active bool) {
+		send(BusySetMsg{id: b.id, active: active})
+	}
+}
+
+func (b *BusyIndicator) Init() tea.Cmd { return nil }
+
+func (b *BusyIndicator) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return b.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *BusyIndicator instead of
+// tea.Model.
+func (b *BusyIndicator) UpdateInto(msg tea.Msg) (*BusyIndicator, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.w, b.h = msg.Width, msg.Height
+	case tapioca.ResizeMsg:
+		b.w, b.h = msg.Width, msg.Height
+	case BusySetMsg:
+		if msg.id == b.id {
+			return b, b.SetActive(msg.active)
+		}
+	case spinner.TickMsg:
+		// ticks are dropped when idle, which stops the timer
+		if b.active && b.anim == nil {
+			var cmd tea.Cmd
+			b.spinner, cmd = b.spinner.Update(msg)
+			return b, cmd
+		}
+	}
+	return b, nil
+}
+
+func (b *BusyIndicator) View() string {
+	if b.w <= 0 || b.h <= 0 {
+		return ""
+	}
+
+	lines := make([]string, b.h)
+	for i := range lines {
+		lines[i] = tapioca.BlankLine(b.w)
+	}
+	if b.active {
+		lines[0] = tapioca.NewEntry(b.spinner.View()+b.Label).StyledTruncate(b.w, '…')
+	}
+	return strings.Join(lines, "\n")
+}
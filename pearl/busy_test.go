@@ -0,0 +1,81 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusy(t *testing.T) {
+	for _, active := range []bool{false, true} {
+		for _, size := range [][2]int{{1, 1}, {5, 1}, {20, 1}, {20, 3}} {
+			b := Busy("working…")
+			b.SetActive(active)
+			assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+				Width:  size[0],
+				Height: size[1],
+				Model:  b,
+			}), "active: %v, %dx%d", active, size[0], size[1])
+		}
+	}
+}
+
+func TestBusy_View(t *testing.T) {
+	b := Busy("working…")
+	b.Update(tapioca.ResizeMsg{Width: 12, Height: 2})
+	assert.Equal(t, "            \n            ", b.View(), "blank when idle")
+
+	var msgs []tea.Msg
+	set := b.Setter(func(msg tea.Msg) { msgs = append(msgs, msg) })
+	set(true)
+	_, cmd := b.UpdateInto(msgs[0])
+	assert.NotNil(t, cmd, "should start ticking")
+	assert.True(t, b.Active())
+	frames := spinner.Dot.Frames
+	assert.Equal(t, frames[0]+"working…  \n            ", b.View())
+
+	b.Update(b.spinner.Tick())
+	assert.Equal(t, frames[1]+"working…  \n            ", b.View())
+
+	b.Label = "a very long label"
+	assert.Equal(t, frames[1]+"a very lo…", strings.Split(b.View(), "\n")[0])
+
+	_, cmd = b.UpdateInto(BusySetMsg{id: b.id + 1, active: false})
+	assert.Nil(t, cmd)
+	assert.True(t, b.Active(), "should ignore message of others")
+
+	set(false)
+	_, cmd = b.UpdateInto(msgs[1])
+	assert.Nil(t, cmd)
+	assert.False(t, b.Active())
+	_, cmd = b.UpdateInto(b.spinner.Tick())
+	assert.Nil(t, cmd, "should stop ticking when idle")
+	assert.Equal(t, "            \n            ", b.View())
+}
+
+func TestBusy_Animator(t *testing.T) {
+	anim := tapioca.NewAnimator(1000)
+	b := Busy("wait")
+	b.SetAnimator(anim)
+	b.Update(tapioca.ResizeMsg{Width: 6, Height: 1})
+
+	assert.NotNil(t, b.SetActive(true), "should start the animator")
+	assert.Nil(t, b.SetActive(true), "should do nothing if not changed")
+	assert.Equal(t, 1, anim.Subscribers())
+
+	now := time.Now()
+	b.onFrame(now)
+	assert.Equal(t, spinner.Dot.Frames[1]+"wait", b.View())
+
+	b.SetActive(false)
+	assert.Equal(t, 0, anim.Subscribers())
+}
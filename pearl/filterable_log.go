@@ -0,0 +1,101 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// FilterableLog is a LogPanel with a filter bar (a [Prompt]) above it.
+//
+// Submitting a query in the filter bar shows only log messages containing it,
+// submitting an empty query shows all messages.
+//
+// Key messages are routed to the filter bar, except up/down/pgup/pgdown,
+// which scroll the log panel. Scroll messages are sent to the log panel.
+type FilterableLog struct {
+	prompt *Prompt
+	lp     *LogPanel
+	w, h   int
+}
+
+// NewFilterableLog creates a FilterableLog with a log panel of size entries.
+// The filter bar is focused by default.
+func NewFilterableLog(size int) *FilterableLog {
+	ret := &FilterableLog{
+		prompt: NewPrompt("Filter: "),
+		lp:     NewLogPanel(size),
+	}
+	ret.prompt.Focus()
+	return ret
+}
+
+// Prompt returns the filter bar, so you can focus or blur it.
+func (f *FilterableLog) Prompt() *Prompt { return f.prompt }
+
+// LogPanel returns the log panel.
+func (f *FilterableLog) LogPanel() *LogPanel { return f.lp }
+
+// CreateWriter returns an io.Writer that writes log messages to the log panel.
+//
+// See [LogPanel.CreateWriter] for details.
+func (f *FilterableLog) CreateWriter(send func(tea.Msg), also io.Writer) io.Writer {
+	return f.lp.CreateWriter(send, also)
+}
+
+func (f *FilterableLog) Init() tea.Cmd {
+	return tea.Batch(f.prompt.Init(), f.lp.Init())
+}
+
+func (f *FilterableLog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return f.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *FilterableLog instead of tea.Model.
+func (f *FilterableLog) UpdateInto(msg tea.Msg) (*FilterableLog, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return f.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		f.w, f.h = msg.Width, msg.Height
+		f.prompt.Update(tapioca.ResizeMsg{Width: msg.Width, Height: min(1, msg.Height)})
+		f.lp, cmd = f.lp.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: max(0, msg.Height-1)})
+	case PromptSubmitMsg:
+		if msg.ID == f.prompt.ID() {
+			f.lp.SetFilter(msg.Value)
+		}
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyUp:
+			f.lp, cmd = f.lp.UpdateInto(tapioca.ScrollUpMsg(1))
+		case tea.KeyDown:
+			f.lp, cmd = f.lp.UpdateInto(tapioca.ScrollDownMsg(1))
+		case tea.KeyPgUp:
+			f.lp, cmd = f.lp.UpdateInto(tapioca.ScrollPageUpMsg{})
+		case tea.KeyPgDown:
+			f.lp, cmd = f.lp.UpdateInto(tapioca.ScrollPageDownMsg{})
+		default:
+			f.prompt, cmd = f.prompt.UpdateInto(msg)
+		}
+	default:
+		f.lp, cmd = f.lp.UpdateInto(msg)
+	}
+	return f, cmd
+}
+
+func (f *FilterableLog) View() string {
+	if f.w <= 0 || f.h <= 0 {
+		return ""
+	}
+	if f.h == 1 {
+		return f.prompt.View()
+	}
+	return f.prompt.View() + "\n" + strings.TrimRight(f.lp.View(), "\n")
+}
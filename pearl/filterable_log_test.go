@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterableLog_Topping(t *testing.T) {
+	cases := []struct {
+		width, height int
+	}{
+		{1, 1},
+		{2, 1},
+		{1, 2},
+		{10, 3},
+		{30, 10},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%dx%d", c.width, c.height), func(t *testing.T) {
+			f := NewFilterableLog(10)
+			w := f.CreateWriter(func(msg tea.Msg) { f.Update(msg) }, nil)
+			w.Write([]byte("line 1\nline 2"))
+
+			assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+				Width:  c.width,
+				Height: c.height,
+				Model:  f,
+			}))
+		})
+	}
+}
+
+func TestFilterableLog(t *testing.T) {
+	f := NewFilterableLog(10)
+	send := func(msg tea.Msg) {
+		_, cmd := f.Update(msg)
+		for cmd != nil {
+			_, cmd = f.Update(cmd())
+		}
+	}
+	w := f.CreateWriter(send, nil)
+	send(tapioca.ResizeMsg{Width: 12, Height: 3})
+	w.Write([]byte("apple\nbanana\napricot"))
+	assert.Equal(t, "banana      \napricot     ", f.View()[len(f.prompt.View())+1:])
+
+	for _, r := range "ap" {
+		send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Equal(t, "ap", f.Prompt().Value())
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "apple       \napricot     ", f.View()[len(f.prompt.View())+1:])
+
+	send(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, "ap", f.Prompt().Value(), "scroll keys should not go to the prompt")
+}
@@ -0,0 +1,281 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// KeyMap provides key bindings shown by [HelpOverlay]. It is identical to
+// KeyMap of github.com/charmbracelet/bubbles/help, so existing key maps can be
+// used directly.
+//
+// Disabled bindings and bindings without help text are not shown.
+type KeyMap interface {
+	// ShortHelp returns bindings shown in the help bar.
+	ShortHelp() []key.Binding
+	// FullHelp returns groups of bindings shown in the full help, each group
+	// is rendered as a column.
+	FullHelp() [][]key.Binding
+}
+
+// HelpOverlay shows a help bar with key bindings at the bottom row, below its
+// child. Bindings not fitting the width are replaced by an ellipsis.
+//
+// Pressing ToggleKey shows full help over the whole area instead of the child,
+// pressing it again or esc closes it. Key messages are not passed to the child
+// while full help is shown. Columns of full help are wrapped to next rows if
+// they do not fit the width.
+//
+// The child is always resized to the area above the help bar.
+//
+// You must create HelpOverlay with NewHelpOverlay().
+type HelpOverlay struct {
+	// ToggleKey toggles full help, "?" by default. Set it to a disabled
+	// binding to toggle only by [HelpOverlay.Toggle].
+	ToggleKey key.Binding
+	// Separator is placed between bindings in the help bar, " • " by default.
+	Separator string
+
+	id    int64
+	child tea.Model
+	keys  KeyMap
+	full  bool
+	w, h  int
+}
+
+// HelpToggleMsg is a message to toggle full help of a HelpOverlay.
+type HelpToggleMsg struct {
+	id int64
+}
+
+// NewHelpOverlay creates a HelpOverlay showing keys below child.
+func NewHelpOverlay(child tea.Model, keys KeyMap) *HelpOverlay {
+	return &HelpOverlay{
+		ToggleKey: key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Separator: " • ",
+		id:        tapioca.NewID(),
+		child:     child,
+		keys:      keys,
+	}
+}
+
+// Full reports whether full help is shown.
+func (o *HelpOverlay) Full() bool { return o.full }
+
+// SetFull shows or hides full help.
+//
+// You should use it only when you are handling an event message.
+func (o *HelpOverlay) SetFull(full bool) { o.full = full }
+
+// Toggle shows or hides full help.
+//
+// You should use it only when you are handling an event message.
+func (o *HelpOverlay) Toggle() { o.full = !o.full }
+
+// Toggler returns a function that sends a HelpToggleMsg.
+func (o *HelpOverlay) Toggler(send func(tea.Msg)) func() {
+	return func() {
+		send(HelpToggleMsg{id: o.id})
+	}
+}
+
+func (o *HelpOverlay) Init() tea.Cmd {
+	return o.child.Init()
+}
+
+func (o *HelpOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return o.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *HelpOverlay instead of
+// tea.Model.
+func (o *HelpOverlay) UpdateInto(msg tea.Msg) (*HelpOverlay, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return o.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		o.w, o.h = msg.Width, msg.Height
+		o.child, cmd = tapioca.Resize(o.child, o.w, max(0, o.h-1))
+	case HelpToggleMsg:
+		if msg.id == o.id {
+			o.Toggle()
+		}
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, o.ToggleKey):
+			o.Toggle()
+		case o.full:
+			if msg.Type == tea.KeyEsc {
+				o.full = false
+			}
+		default:
+			o.child, cmd = o.child.Update(msg)
+		}
+	default:
+		o.child, cmd = o.child.Update(msg)
+	}
+	return o, cmd
+}
+
+func (o *HelpOverlay) View() string {
+	if o.w <= 0 || o.h <= 0 {
+		return ""
+	}
+	if o.full {
+		return strings.Join(o.fullLines(), "\n")
+	}
+
+	bar := o.barLine()
+	if o.h == 1 {
+		return bar
+	}
+	return o.child.View() + "\n" + bar
+}
+
+// helpItems returns help text of enabled bindings
+func helpItems(bindings []key.Binding) []key.Help {
+	ret := make([]key.Help, 0, len(bindings))
+	for _, b := range bindings {
+		if h := b.Help(); b.Enabled() && h.Key != "" {
+			ret = append(ret, h)
+		}
+	}
+	return ret
+}
+
+// barLine renders short help in a single line
+//
+//	"q quit • ? help • …"
+func (o *HelpOverlay) barLine() string {
+	items := helpItems(o.keys.ShortHelp())
+	sepWidth := tapioca.DisplayWidth(o.Separator)
+	buf := &strings.Builder{}
+	used := 0
+	for i, h := range items {
+		item := "\x1b[1m" + h.Key + "\x1b[0m " + h.Desc
+		w := tapioca.DisplayWidth(h.Key) + 1 + tapioca.DisplayWidth(h.Desc)
+		if i > 0 {
+			w += sepWidth
+		}
+		// leave room for ellipsis if it is not the last one
+		need := used + w
+		if i < len(items)-1 {
+			need += sepWidth + 1
+		}
+		if need > o.w {
+			if i > 0 {
+				buf.WriteString(o.Separator)
+			}
+			buf.WriteString("…")
+			break
+		}
+		if i > 0 {
+			buf.WriteString(o.Separator)
+		}
+		buf.WriteString(item)
+		used += w
+	}
+	return tapioca.NewEntry(buf.String()).StyledTruncate(o.w, '…')
+}
+
+// fullLines renders full help in o.h lines. Each group is a column, columns
+// are wrapped to next rows when exceeding the width.
+func (o *HelpOverlay) fullLines() []string {
+	const gap = "    "
+
+	var lines []string
+	var row []helpColumn
+	rowWidth := 0
+	flush := func() {
+		if len(row) == 0 {
+			return
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, joinColumns(row, gap)...)
+		row, rowWidth = nil, 0
+	}
+	for _, group := range o.keys.FullHelp() {
+		col := newHelpColumn(helpItems(group))
+		if len(col.lines) == 0 {
+			continue
+		}
+		if len(row) > 0 && rowWidth+len(gap)+col.width > o.w {
+			flush()
+		}
+		if len(row) > 0 {
+			rowWidth += len(gap)
+		}
+		row = append(row, col)
+		rowWidth += col.width
+	}
+	flush()
+
+	ret := make([]string, o.h)
+	for i := range ret {
+		if i < len(lines) {
+			ret[i] = tapioca.NewEntry(lines[i]).StyledTruncate(o.w, '…')
+			continue
+		}
+		ret[i] = tapioca.BlankLine(o.w)
+	}
+	return ret
+}
+
+// helpColumn is a group of bindings rendered as aligned lines
+type helpColumn struct {
+	lines []string
+	width int
+}
+
+func newHelpColumn(items []key.Help) helpColumn {
+	keyWidth := 0
+	for _, h := range items {
+		keyWidth = max(keyWidth, tapioca.DisplayWidth(h.Key))
+	}
+
+	var ret helpColumn
+	for _, h := range items {
+		pad := strings.Repeat(" ", keyWidth-tapioca.DisplayWidth(h.Key))
+		ret.lines = append(ret.lines, "\x1b[1m"+h.Key+"\x1b[0m"+pad+"  "+h.Desc)
+		ret.width = max(ret.width, keyWidth+2+tapioca.DisplayWidth(h.Desc))
+	}
+	return ret
+}
+
+// joinColumns places columns side by side
+func joinColumns(cols []helpColumn, gap string) []string {
+	n := 0
+	for _, c := range cols {
+		n = max(n, len(c.lines))
+	}
+
+	ret := make([]string, n)
+	for i := range ret {
+		buf := &strings.Builder{}
+		for j, c := range cols {
+			if j > 0 {
+				buf.WriteString(gap)
+			}
+			line := ""
+			if i < len(c.lines) {
+				line = c.lines[i]
+			}
+			if j < len(cols)-1 {
+				line = tapioca.NewEntry(line).StyledMove(0, c.width)
+			}
+			buf.WriteString(line)
+		}
+		ret[i] = buf.String()
+	}
+	return ret
+}
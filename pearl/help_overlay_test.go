@@ -0,0 +1,149 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+type testKeyMap struct {
+	quit, up, down, hidden key.Binding
+}
+
+func newTestKeyMap() testKeyMap {
+	return testKeyMap{
+		quit:   key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+		up:     key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "scroll up")),
+		down:   key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "scroll down")),
+		hidden: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "hidden"), key.WithDisabled()),
+	}
+}
+
+func (k testKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.quit, k.hidden, k.up, k.down}
+}
+
+func (k testKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.up, k.down}, {k.quit, k.hidden}}
+}
+
+func TestHelpOverlay(t *testing.T) {
+	cases := []struct {
+		width, height int
+	}{
+		{1, 1},
+		{5, 1},
+		{1, 5},
+		{10, 3},
+		{40, 2},
+		{40, 10},
+	}
+
+	for _, c := range cases {
+		for _, full := range []bool{false, true} {
+			o := NewHelpOverlay(NewSpan(), newTestKeyMap())
+			o.SetFull(full)
+			assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+				Width:  c.width,
+				Height: c.height,
+				Model:  o,
+			}), "%dx%d full=%v", c.width, c.height, full)
+		}
+	}
+}
+
+func TestHelpOverlay_Bar(t *testing.T) {
+	cases := []struct {
+		width    int
+		expected string
+	}{
+		{40, "\x1b[1mq\x1b[0m quit • \x1b[1m↑\x1b[0m scroll up • \x1b[1m↓\x1b[0m scroll down    "},
+		{30, "\x1b[1mq\x1b[0m quit • \x1b[1m↑\x1b[0m scroll up • …      "},
+		{10, "\x1b[1mq\x1b[0m quit • …"},
+		{5, "…    "},
+	}
+
+	for _, c := range cases {
+		o := NewHelpOverlay(NewSpan(), newTestKeyMap())
+		o.Update(tapioca.ResizeMsg{Width: c.width, Height: 1})
+		assert.Equal(t, c.expected, o.View(), "width %d", c.width)
+	}
+}
+
+func TestHelpOverlay_Full(t *testing.T) {
+	o := NewHelpOverlay(NewSpan(), newTestKeyMap())
+	o.Update(tapioca.ResizeMsg{Width: 30, Height: 6})
+	o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	assert.True(t, o.Full())
+
+	lines := strings.Split(o.View(), "\n")
+	assert.Len(t, lines, 6)
+	assert.Equal(t, "↑  scroll up      q  quit     ", tapioca.NewEntry(lines[0]).String())
+	assert.Equal(t, "↓  scroll down                ", tapioca.NewEntry(lines[1]).String())
+
+	// columns are wrapped
+	o.Update(tapioca.ResizeMsg{Width: 20, Height: 6})
+	lines = strings.Split(o.View(), "\n")
+	assert.Equal(t, "↑  scroll up        ", tapioca.NewEntry(lines[0]).String())
+	assert.Equal(t, "↓  scroll down      ", tapioca.NewEntry(lines[1]).String())
+	assert.Equal(t, "                    ", tapioca.NewEntry(lines[2]).String())
+	assert.Equal(t, "q  quit             ", tapioca.NewEntry(lines[3]).String())
+
+	o.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, o.Full())
+}
+
+func TestHelpOverlay_Keys(t *testing.T) {
+	p := NewPrompt("")
+	p.Focus()
+	o := NewHelpOverlay(p, newTestKeyMap())
+	o.Update(tapioca.ResizeMsg{Width: 20, Height: 3})
+
+	o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	assert.Equal(t, "a", p.Value())
+
+	// keys are not passed to child when full help is shown
+	o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	assert.Equal(t, "a", p.Value())
+	assert.True(t, o.Full())
+
+	o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	assert.False(t, o.Full())
+
+	var msgs []tea.Msg
+	o.Toggler(func(m tea.Msg) { msgs = append(msgs, m) })()
+	o.Update(msgs[0])
+	assert.True(t, o.Full())
+	o.Update(HelpToggleMsg{})
+	assert.True(t, o.Full(), "message for other overlay is ignored")
+}
+
+// resizableSpan records size passed to OnResize
+type resizableSpan struct {
+	*Span
+	w, h int
+}
+
+func (r *resizableSpan) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	r.Span.Update(msg)
+	return r, nil
+}
+
+func (r *resizableSpan) OnResize(w, h int) { r.w, r.h = w, h }
+
+func TestHelpOverlay_Resizable(t *testing.T) {
+	child := &resizableSpan{Span: NewSpan()}
+	o := NewHelpOverlay(child, newTestKeyMap())
+	o.Update(tapioca.ResizeMsg{Width: 20, Height: 5})
+	assert.Equal(t, 20, child.w)
+	assert.Equal(t, 4, child.h, "last row is taken by help line")
+}
@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// LatestBlockSetMsg is a message to replace the content of a LatestBlock.
+type LatestBlockSetMsg struct {
+	id   int64
+	data string
+}
+
+// LatestBlock displays only the latest message, like a status line which is
+// updated repeatedly. Setting new message overwrites the previous one.
+//
+// The message can be styled and contain multiple lines. Each line is wrapped
+// to fit the width, and the result is clipped to at most height rows. Rows
+// below the message are blank.
+type LatestBlock struct {
+	id     int64
+	height int
+	lines  []*tapioca.Entry
+	w, h   int
+}
+
+// NewLatestBlock creates a LatestBlock which shows at most height rows of the
+// message. Pass 0 to use all rows of the component.
+func NewLatestBlock(height int) *LatestBlock {
+	return &LatestBlock{
+		id:     tapioca.NewID(),
+		height: height,
+	}
+}
+
+// Set replaces current message with data.
+//
+// You should use it only when you are handling an event message.
+func (b *LatestBlock) Set(data string) {
+	arr := strings.Split(strings.TrimRight(data, "\n"), "\n")
+	b.lines = make([]*tapioca.Entry, len(arr))
+	for i, l := range arr {
+		b.lines[i] = tapioca.NewEntry(l)
+	}
+}
+
+// Setter returns a function that sends a LatestBlockSetMsg to replace the
+// message.
+func (b *LatestBlock) Setter(send func(tea.Msg)) func(string) {
+	return func(data string) {
+		send(LatestBlockSetMsg{id: b.id, data: data})
+	}
+}
+
+func (b *LatestBlock) Init() tea.Cmd { return nil }
+
+func (b *LatestBlock) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return b.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *LatestBlock instead of
+// tea.Model.
+func (b *LatestBlock) UpdateInto(msg tea.Msg) (*LatestBlock, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.w, b.h = msg.Width, msg.Height
+	case tapioca.ResizeMsg:
+		b.w, b.h = msg.Width, msg.Height
+	case LatestBlockSetMsg:
+		if msg.id == b.id {
+			b.Set(msg.data)
+		}
+	}
+	return b, nil
+}
+
+func (b *LatestBlock) View() string {
+	if b.w <= 0 || b.h <= 0 {
+		return ""
+	}
+
+	rows := b.h
+	if b.height > 0 {
+		rows = min(rows, b.height)
+	}
+
+	ret := make([]string, 0, b.h)
+	for _, l := range b.lines {
+		for _, s := range l.StyledBlock(b.w) {
+			if len(ret) >= rows {
+				break
+			}
+			ret = append(ret, s)
+		}
+	}
+	for len(ret) < b.h {
+		ret = append(ret, tapioca.BlankLine(b.w))
+	}
+	return strings.Join(ret, "\n")
+}
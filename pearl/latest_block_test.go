@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestBlock_Topping(t *testing.T) {
+	cases := []struct {
+		width, height int
+	}{
+		{1, 1},
+		{2, 2},
+		{10, 3},
+		{3, 10},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%dx%d", c.width, c.height), func(t *testing.T) {
+			b := NewLatestBlock(2)
+			b.Set(strings.Repeat("A", 100) + "\n中文")
+			assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+				Width:  c.width,
+				Height: c.height,
+				Model:  b,
+			}))
+		})
+	}
+}
+
+func TestLatestBlock(t *testing.T) {
+	b := NewLatestBlock(2)
+	b.Update(tapioca.ResizeMsg{Width: 4, Height: 3})
+	setter := b.Setter(func(msg tea.Msg) { b.Update(msg) })
+
+	setter("first")
+	assert.Equal(t, "firs\nt   \n    ", b.View())
+
+	setter("ok")
+	assert.Equal(t, "ok  \n    \n    ", b.View())
+
+	setter("a\nbb\nccc")
+	assert.Equal(t, "a   \nbb  \n    ", b.View(), "clipped to 2 rows")
+
+	b = NewLatestBlock(0)
+	b.Update(tapioca.ResizeMsg{Width: 4, Height: 3})
+	b.Set("a\nbb\nccc\ndddd")
+	assert.Equal(t, "a   \nbb  \nccc ", b.View())
+}
@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"bytes"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LevelRouter is an io.Writer which routes log lines to LogPanels by their
+// level, so you can show errors in a dedicated panel without changing how you
+// write logs.
+//
+// Every line is written to the main panel, and also to the panel of its level
+// if there's one. Messages sent are [LogPanelWriteMsg], so other LogPanels
+// are not affected.
+//
+// You must create LevelRouter with NewLevelRouter().
+type LevelRouter struct {
+	send   func(tea.Msg)
+	detect func(line string) string
+	main   *LogPanel
+	panels map[string]*LogPanel
+	lock   sync.Mutex
+}
+
+// NewLevelRouter creates a LevelRouter. detect returns level of a line, which
+// is used as key of panels. main can be nil to write routed lines only.
+//
+//	router := pearl.NewLevelRouter(send, detect, mainPanel, map[string]*pearl.LogPanel{
+//		"ERROR": errorPanel,
+//	})
+//	logger := log.New(router, "", log.LstdFlags)
+func NewLevelRouter(send func(tea.Msg), detect func(line string) string, main *LogPanel, panels map[string]*LogPanel) *LevelRouter {
+	if send == nil {
+		panic("send is nil")
+	}
+	if detect == nil {
+		panic("detect is nil")
+	}
+	return &LevelRouter{
+		send:   send,
+		detect: detect,
+		main:   main,
+		panels: panels,
+	}
+}
+
+func (r *LevelRouter) Write(p []byte) (int, error) {
+	data := bytes.TrimRight(p, "\n")
+	lines := bytes.Split(data, []byte{'\n'})
+
+	// lines for each panel, in order of first appearance
+	var order []*LogPanel
+	routed := map[*LogPanel][][]byte{}
+	r.lock.Lock()
+	for _, line := range lines {
+		lp := r.panels[r.detect(string(line))]
+		if lp == nil || lp == r.main {
+			continue
+		}
+		if _, ok := routed[lp]; !ok {
+			order = append(order, lp)
+		}
+		routed[lp] = append(routed[lp], line)
+	}
+	r.lock.Unlock()
+
+	if r.main != nil {
+		r.main.Sender(r.send)(bytes.Clone(data))
+	}
+	for _, lp := range order {
+		lp.Sender(r.send)(bytes.Join(routed[lp], []byte{'\n'}))
+	}
+	return len(p), nil
+}
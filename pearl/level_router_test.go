@@ -0,0 +1,48 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelRouter(t *testing.T) {
+	main, errs := NewLogPanel(10), NewLogPanel(10)
+	main.Update(tapioca.ResizeMsg{Width: 7, Height: 3})
+	errs.Update(tapioca.ResizeMsg{Width: 7, Height: 3})
+	send := func(msg tea.Msg) {
+		main.Update(msg)
+		errs.Update(msg)
+	}
+	detect := func(line string) string {
+		level, _, _ := strings.Cut(line, " ")
+		return level
+	}
+
+	w := NewLevelRouter(send, detect, main, map[string]*LogPanel{"ERR": errs})
+	n, err := w.Write([]byte("INF a\nERR b\nINF c\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 18, n)
+	w.Write([]byte("ERR d"))
+
+	assert.Equal(t, "ERR b  \nINF c  \nERR d  ", main.View())
+	assert.Equal(t, "ERR b  \nERR d  \n       ", errs.View())
+}
+
+func TestLevelRouter_NoMain(t *testing.T) {
+	errs := NewLogPanel(10)
+	errs.Update(tapioca.ResizeMsg{Width: 5, Height: 1})
+	w := NewLevelRouter(func(msg tea.Msg) { errs.Update(msg) }, func(line string) string {
+		return line[:1]
+	}, nil, map[string]*LogPanel{"E": errs})
+
+	w.Write([]byte("I1\nE2"))
+	assert.Equal(t, "E2   ", errs.View())
+}
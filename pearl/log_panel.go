@@ -18,26 +18,68 @@ import (
 //
 // You must create LogPanel with NewLogPanel().
 //
-// LogPanel does not support manually scrolling.
+// New log messages and resizing scroll the panel to the bottom only if it is
+// already at the bottom, so user can read history with
+// [LogPanel.ScrollController] without being interrupted. It can be disabled
+// by [LogPanel.SetFollow].
+//
+// LogPanel supports [tapioca.BatchMsg], LogMsg and LogPanelWriteMsg in a batch
+// are added at once.
+//
+// Carriage return and erase line sequences are handled like a terminal, see
+// [tapioca.Entry.Redraw]. Since each write is a new line, a message starting
+// with "\r" redraws the newest line instead, so progress output of tools like
+// curl is shown in place.
 type LogPanel struct {
 	// if true, new log messages are placed at the top
 	// by default, new log messages are placed at the bottom
 	Reverse bool
+	// if true, log messages are treated as plain text without ANSI escape
+	// sequences, which skips parsing them
+	NoANSI bool
 
+	id   int64
 	impl *BufferedBlock
+	// byte size of each entry, in same order as entries in impl
+	sizes  *tapioca.CircularBuffer[int]
+	bytes  int
+	budget int
 }
 
 // LogMsg denotes a logger has written a log message to LogPanel.
 type LogMsg []byte
 
+// LogPanelWriteMsg is like LogMsg, but only handled by the LogPanel it is
+// created for. See [LogPanel.Sender].
+type LogPanelWriteMsg struct {
+	id   int64
+	data LogMsg
+}
+
+// LogPanelSetFollowMsg is a message to pause or resume following new log
+// messages of a LogPanel.
+type LogPanelSetFollowMsg struct {
+	id     int64
+	follow bool
+}
+
+// LogPanelSetWrapMsg is a message to switch line wrap mode of a LogPanel.
+type LogPanelSetWrapMsg struct {
+	id   int64
+	wrap bool
+}
+
 // NewLogPanel creates a new LogPanel.
 func NewLogPanel(size int) *LogPanel {
 	if size < 10 {
 		size = 10
 	}
 	lp := &LogPanel{
-		impl: NewBufferedBlock(size, false, true),
+		id:    tapioca.NewID(),
+		impl:  NewBufferedBlock(size, false, true),
+		sizes: tapioca.NewCircularBuffer[int](size),
 	}
+	lp.impl.SetFollow(true)
 	return lp
 }
 
@@ -45,6 +87,212 @@ func (lp *LogPanel) ScrollController() tapioca.ScrollController {
 	return lp.impl
 }
 
+// SetFollow enables or disables following new log messages, see
+// [BufferedBlock.SetFollow]. Default to true. If disabled, the panel is never
+// scrolled by new messages or resizing, so user can review logs with scroll
+// messages while the job keeps writing. Enabling it again scrolls to the
+// bottom.
+//
+// You should use it only when you are handling an event message.
+func (lp *LogPanel) SetFollow(enable bool) {
+	if enable && !lp.impl.Follow() && !lp.Reverse {
+		lp.impl.ScrollToBottom()
+	}
+	lp.impl.SetFollow(enable)
+}
+
+// Follow reports whether the panel follows new log messages.
+func (lp *LogPanel) Follow() bool {
+	return lp.impl.Follow()
+}
+
+// FollowSetter returns a function that sends a LogPanelSetFollowMsg to pause
+// or resume following new log messages.
+func (lp *LogPanel) FollowSetter(send func(tea.Msg)) func(bool) {
+	return func(follow bool) {
+		send(LogPanelSetFollowMsg{id: lp.id, follow: follow})
+	}
+}
+
+// Sender returns a function that sends a LogPanelWriteMsg to add log messages
+// to this panel only.
+func (lp *LogPanel) Sender(send func(tea.Msg)) func([]byte) {
+	return func(data []byte) {
+		send(LogPanelWriteMsg{id: lp.id, data: data})
+	}
+}
+
+// SetWrapPolicy changes how long log messages are broken into lines.
+//
+// See [BufferedBlock.SetWrapPolicy] for details.
+func (lp *LogPanel) SetWrapPolicy(p tapioca.WrapPolicy) {
+	lp.impl.SetWrapPolicy(p)
+}
+
+// SetWrap switches between line wrap mode (default) and no-wrap mode, in which
+// long log messages are scrolled horizontally. Existing messages are kept.
+//
+// You should use it only when you are handling an event message.
+func (lp *LogPanel) SetWrap(wrap bool) {
+	lp.impl.SetLineWrap(wrap)
+	if !lp.Reverse {
+		lp.impl.ScrollToBottom()
+	}
+}
+
+// WrapSetter returns a function that sends a LogPanelSetWrapMsg to switch line
+// wrap mode.
+func (lp *LogPanel) WrapSetter(send func(tea.Msg)) func(bool) {
+	return func(wrap bool) {
+		send(LogPanelSetWrapMsg{id: lp.id, wrap: wrap})
+	}
+}
+
+// SetFilter shows only log messages containing query, ANSI styles are ignored
+// when matching. Empty query shows all messages.
+//
+// You should use it only when you are handling an event message.
+func (lp *LogPanel) SetFilter(query string) {
+	if query == "" {
+		lp.impl.SetFilter(nil)
+	} else {
+		lp.impl.SetFilter(func(e *tapioca.Entry) bool {
+			return strings.Contains(e.String(), query)
+		})
+	}
+	if !lp.Reverse {
+		lp.impl.ScrollToBottom()
+	}
+}
+
+// SetByteBudget limits total size of stored log messages to n bytes, in
+// addition to the count limit set by NewLogPanel. Oldest messages are dropped
+// when exceeded, but the newest one is always kept. Size of a message is the
+// length of the line written, not the memory it takes after parsing.
+//
+// n <= 0 disables the limit, which is the default.
+//
+// You should use it only when you are handling an event message.
+func (lp *LogPanel) SetByteBudget(n int) {
+	lp.budget = max(0, n)
+	lp.shrink()
+	lp.impl.recomputeCachedInfo()
+}
+
+// add stores the entry without updating cached info of impl
+func (lp *LogPanel) add(e *tapioca.Entry, size int) {
+	entries := lp.impl.entries
+	if entries.Size() == entries.Capacity() {
+		lp.dropOldest()
+	}
+
+	if lp.Reverse {
+		entries.Prepend(e)
+		lp.sizes.Prepend(size)
+	} else {
+		entries.Append(e)
+		lp.sizes.Append(size)
+	}
+	lp.bytes += size
+	lp.shrink()
+}
+
+// shrink drops oldest messages until total size fits the budget
+func (lp *LogPanel) shrink() {
+	for lp.budget > 0 && lp.bytes > lp.budget && lp.sizes.Size() > 1 {
+		lp.dropOldest()
+	}
+}
+
+// redrawNewest replaces newest message with data written over it
+func (lp *LogPanel) redrawNewest(data string) {
+	pop := lp.sizes.PopBack
+	popEntry := lp.impl.entries.PopBack
+	if lp.Reverse {
+		pop = lp.sizes.PopFront
+		popEntry = lp.impl.entries.PopFront
+	}
+
+	size, _ := pop()
+	e, _ := popEntry()
+	lp.bytes -= size
+	lp.add(e.Redraw(data), len(data))
+}
+
+func (lp *LogPanel) dropOldest() {
+	pop := lp.sizes.PopFront
+	popEntry := lp.impl.entries.PopFront
+	if lp.Reverse {
+		pop = lp.sizes.PopBack
+		popEntry = lp.impl.entries.PopBack
+	}
+
+	size, _ := pop()
+	popEntry()
+	lp.bytes -= size
+}
+
+// addLog stores lines of msg without updating cached info of impl
+func (lp *LogPanel) addLog(msg LogMsg) {
+	lines := bytes.Split(msg, []byte{'\n'})
+	newEntry := tapioca.NewEntry
+	if lp.NoANSI {
+		newEntry = tapioca.NewPlainEntry
+	}
+
+	entries := make([]*tapioca.Entry, 0, len(lines))
+	sizes := make([]int, 0, len(lines))
+	for i, line := range lines {
+		str := string(line)
+		switch {
+		case lp.NoANSI || !tapioca.HasRedraw(str):
+			entries = append(entries, newEntry(str))
+		case i == 0 && str[0] == '\r' && lp.sizes.Size() > 0:
+			lp.redrawNewest(str)
+			continue
+		default:
+			entries = append(entries, tapioca.NewEntry("").Redraw(str))
+		}
+		sizes = append(sizes, len(line))
+	}
+	lp.addAll(entries, sizes)
+}
+
+// addAll stores entries at once without updating cached info of impl. It is
+// identical to calling add for each entry.
+func (lp *LogPanel) addAll(entries []*tapioca.Entry, sizes []int) {
+	if lp.Reverse {
+		for i, e := range entries {
+			lp.add(e, sizes[i])
+		}
+		return
+	}
+
+	// count size of entries evicted by the batch, including new ones
+	buf := lp.impl.entries
+	evict := max(0, buf.Size()+len(entries)-buf.Capacity())
+	old := min(evict, lp.sizes.Size())
+	for i := range old {
+		size, _ := lp.sizes.At(i)
+		lp.bytes -= size
+	}
+	for _, size := range sizes[evict-old:] {
+		lp.bytes += size
+	}
+
+	buf.AppendAll(entries...)
+	lp.sizes.AppendAll(sizes...)
+	lp.shrink()
+}
+
+// afterAdd updates cached info after new messages are stored
+func (lp *LogPanel) afterAdd(follow bool) {
+	lp.impl.recomputeCachedInfo()
+	if !lp.Reverse && follow {
+		lp.impl.ScrollToBottom()
+	}
+}
+
 func (lp *LogPanel) Init() tea.Cmd {
 	return lp.impl.Init()
 }
@@ -57,27 +305,55 @@ func (lp *LogPanel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (lp *LogPanel) UpdateInto(msg tea.Msg) (*LogPanel, tea.Cmd) {
 	var cmds []tea.Cmd
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return lp.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
 	case LogMsg:
-		lines := bytes.Split(msg, []byte{'\n'})
-		add := lp.impl.Append
-		if lp.Reverse {
-			add = lp.impl.Prepend
+		// follow new messages only if user is not reading history
+		follow := lp.impl.following()
+		lp.addLog(msg)
+		lp.afterAdd(follow)
+	case LogPanelWriteMsg:
+		if msg.id == lp.id {
+			return lp.UpdateInto(msg.data)
 		}
-
-		for _, line := range lines {
-			add(string(line))
+	case tapioca.BatchMsg:
+		follow := lp.impl.following()
+		added := false
+		for _, m := range msg {
+			if l, ok := m.(LogMsg); ok {
+				lp.addLog(l)
+				added = true
+				continue
+			}
+			if l, ok := m.(LogPanelWriteMsg); ok && l.id == lp.id {
+				lp.addLog(l.data)
+				added = true
+				continue
+			}
+			if _, cmd := lp.UpdateInto(m); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
-
-		if !lp.Reverse {
-			lp.impl.ScrollToBottom()
+		if added {
+			lp.afterAdd(follow)
+		}
+	case LogPanelSetWrapMsg:
+		if msg.id == lp.id {
+			lp.SetWrap(msg.wrap)
+		}
+	case LogPanelSetFollowMsg:
+		if msg.id == lp.id {
+			lp.SetFollow(msg.follow)
 		}
 	case tapioca.ResizeMsg:
+		// like new messages, do not move a user reading history
+		follow := lp.impl.following()
 		var cmd tea.Cmd
 		lp.impl, cmd = lp.impl.UpdateInto(msg)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
-		if !lp.Reverse {
+		if !lp.Reverse && follow {
 			lp.impl.ScrollToBottom()
 		}
 	default:
@@ -161,7 +437,8 @@ func (w *logWriterImpl) Write(p []byte) (n int, err error) {
 	w.lock.Unlock()
 
 	if err == nil {
-		w.send(LogMsg(bytes.TrimRight(p, "\n")))
+		// p must not be retained, see io.Writer
+		w.send(LogMsg(bytes.Clone(bytes.TrimRight(p, "\n"))))
 	}
 
 	return
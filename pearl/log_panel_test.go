@@ -6,7 +6,9 @@ package pearl
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -47,3 +49,328 @@ func TestLogPanel(t *testing.T) {
 		})
 	}
 }
+
+func TestLogPanel_NoANSI(t *testing.T) {
+	b := NewLogPanel(10)
+	b.NoANSI = true
+	b.Update(tapioca.ResizeMsg{Width: 10, Height: 1})
+	b.Update(LogMsg("plain text"))
+	assert.Equal(t, "plain text", b.View())
+}
+
+func TestLogPanel_WindowSizeMsg(t *testing.T) {
+	lp := NewLogPanel(10)
+	lp.Update(LogMsg("1\n2\n3"))
+	lp.Update(tea.WindowSizeMsg{Width: 3, Height: 2})
+	assert.Equal(t, "2  \n3  ", lp.View())
+}
+
+func TestLogPanel_SetFilter(t *testing.T) {
+	lp := NewLogPanel(10)
+	lp.Update(tapioca.ResizeMsg{Width: 5, Height: 2})
+	lp.Update(LogMsg("err 1\nok 2\n\x1b[31merr\x1b[m 3\nok 4"))
+	assert.Equal(t, "\x1b[31merr\x1b[0m 3\nok 4 ", lp.View())
+
+	lp.SetFilter("ok")
+	assert.Equal(t, "ok 2 \nok 4 ", lp.View())
+
+	lp.SetFilter("err")
+	assert.Equal(t, "err 1\n\x1b[31merr\x1b[0m 3", lp.View())
+
+	lp.SetFilter("none")
+	assert.Equal(t, "     \n     ", lp.View())
+
+	lp.SetFilter("")
+	assert.Equal(t, "\x1b[31merr\x1b[0m 3\nok 4 ", lp.View())
+}
+
+func TestLogPanel_SetByteBudget(t *testing.T) {
+	messages := plainEntries
+
+	t.Run("drop oldest", func(t *testing.T) {
+		lp := NewLogPanel(10)
+		lp.SetByteBudget(10)
+		lp.Update(LogMsg("aaaa\nbbbb"))
+		assert.Equal(t, []string{"aaaa", "bbbb"}, messages(lp))
+
+		lp.Update(LogMsg("cc"))
+		assert.Equal(t, []string{"aaaa", "bbbb", "cc"}, messages(lp))
+
+		lp.Update(LogMsg("d"))
+		assert.Equal(t, []string{"bbbb", "cc", "d"}, messages(lp))
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		lp := NewLogPanel(10)
+		lp.Reverse = true
+		lp.SetByteBudget(5)
+		lp.Update(LogMsg("aaa\nbbb"))
+		assert.Equal(t, []string{"bbb"}, messages(lp))
+	})
+
+	t.Run("newest is kept", func(t *testing.T) {
+		lp := NewLogPanel(10)
+		lp.SetByteBudget(3)
+		lp.Update(LogMsg("a\nhuge line"))
+		assert.Equal(t, []string{"huge line"}, messages(lp))
+	})
+
+	t.Run("with count limit", func(t *testing.T) {
+		lp := NewLogPanel(10)
+		lp.SetByteBudget(100)
+		for i := range 12 {
+			lp.Update(LogMsg(fmt.Sprint(i)))
+		}
+		assert.Equal(t, 10, lp.sizes.Size())
+		// 0 and 1 are dropped by count limit
+		assert.Equal(t, 8+2*2, lp.bytes)
+
+		lp.SetByteBudget(4)
+		assert.Equal(t, []string{"10", "11"}, messages(lp))
+		assert.Equal(t, 4, lp.bytes)
+	})
+}
+
+func TestLogPanel_SetWrap(t *testing.T) {
+	lp := NewLogPanel(10)
+	lp.Update(tapioca.ResizeMsg{Width: 5, Height: 2})
+	lp.Update(LogMsg("short\nlong message"))
+	assert.Equal(t, "messa\nge   ", lp.View())
+
+	send := func(msg tea.Msg) { lp.Update(msg) }
+	lp.WrapSetter(send)(false)
+	assert.Equal(t, "short\nlong ", lp.View())
+
+	lp.Update(tapioca.ScrollEndMsg{})
+	assert.Equal(t, "     \nssage", lp.View())
+
+	// messages for other panel are ignored
+	NewLogPanel(10).WrapSetter(send)(true)
+	assert.Equal(t, "     \nssage", lp.View())
+
+	lp.SetWrap(true)
+	assert.Equal(t, "messa\nge   ", lp.View())
+}
+
+func TestLogPanel_StickyBottom(t *testing.T) {
+	newPanel := func() *LogPanel {
+		lp := NewLogPanel(10)
+		lp.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+		lp.Update(LogMsg("a\nb\nc"))
+		return lp
+	}
+
+	t.Run("at bottom", func(t *testing.T) {
+		lp := newPanel()
+		assert.Equal(t, "b  \nc  ", lp.View())
+		lp.Update(LogMsg("d"))
+		assert.Equal(t, "c  \nd  ", lp.View())
+	})
+
+	t.Run("scrolled up", func(t *testing.T) {
+		lp := newPanel()
+		lp.Update(tapioca.ScrollUpMsg(1))
+		lp.Update(LogMsg("d"))
+		assert.Equal(t, "a  \nb  ", lp.View(), "should stay put")
+
+		// back to bottom, follow again
+		lp.Update(tapioca.ScrollBottomMsg{})
+		lp.Update(LogMsg("e"))
+		assert.Equal(t, "d  \ne  ", lp.View())
+	})
+
+	t.Run("resize at bottom", func(t *testing.T) {
+		lp := newPanel()
+		lp.Update(tapioca.ResizeMsg{Width: 2, Height: 1})
+		assert.Equal(t, "c ", lp.View())
+		lp.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+		assert.Equal(t, "b  \nc  ", lp.View())
+	})
+
+	t.Run("resize while scrolled up", func(t *testing.T) {
+		lp := newPanel()
+		lp.Update(tapioca.ScrollTopMsg{})
+		lp.Update(tapioca.ResizeMsg{Width: 2, Height: 1})
+		assert.Equal(t, "a ", lp.View(), "should stay put")
+		lp.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+		assert.Equal(t, "a  \nb  ", lp.View(), "should stay put")
+
+		// still following after back to bottom
+		lp.Update(tapioca.ScrollBottomMsg{})
+		lp.Update(tapioca.ResizeMsg{Width: 3, Height: 1})
+		assert.Equal(t, "c  ", lp.View())
+	})
+
+	t.Run("follow disabled", func(t *testing.T) {
+		lp := newPanel()
+		lp.SetFollow(false)
+		lp.Update(LogMsg("d"))
+		assert.Equal(t, "b  \nc  ", lp.View(), "should stay put")
+		lp.Update(tapioca.ResizeMsg{Width: 3, Height: 1})
+		assert.Equal(t, "b  ", lp.View(), "should stay put")
+
+		lp.SetFollow(true)
+		lp.Update(tapioca.ScrollBottomMsg{})
+		lp.Update(LogMsg("e"))
+		assert.Equal(t, "e  ", lp.View())
+	})
+}
+
+func TestLogPanel_FollowSetter(t *testing.T) {
+	lp := NewLogPanel(10)
+	var msgs []tea.Msg
+	pause := lp.FollowSetter(func(m tea.Msg) { msgs = append(msgs, m) })
+	lp.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+	lp.Update(LogMsg("a\nb\nc"))
+
+	// scroll messages take effect and new logs keep the position
+	lp.Update(tapioca.ScrollUpMsg(1))
+	lp.Update(LogMsg("d"))
+	assert.Equal(t, "a  \nb  ", lp.View())
+
+	pause(false)
+	lp.Update(msgs[0])
+	assert.False(t, lp.Follow())
+	lp.Update(tapioca.ScrollBottomMsg{})
+	lp.Update(LogMsg("e"))
+	assert.Equal(t, "c  \nd  ", lp.View(), "paused even at bottom")
+
+	// resuming jumps to newest logs
+	pause(true)
+	lp.Update(msgs[1])
+	assert.True(t, lp.Follow())
+	assert.Equal(t, "d  \ne  ", lp.View())
+	lp.Update(LogMsg("f"))
+	assert.Equal(t, "e  \nf  ", lp.View())
+}
+
+func TestLogPanel_Redraw(t *testing.T) {
+	lp := NewLogPanel(10)
+	lp.Update(tapioca.ResizeMsg{Width: 6, Height: 3})
+
+	lp.Update(LogMsg("start"))
+	lp.Update(LogMsg("\r10%"))
+	assert.Equal(t, "10%rt \n      \n      ", lp.View())
+	lp.Update(LogMsg("\r100%\x1b[K"))
+	assert.Equal(t, "100%  \n      \n      ", lp.View())
+
+	// only first line of a message redraws previous one
+	lp.Update(LogMsg("\rdone\x1b[K\n\rnext"))
+	assert.Equal(t, "done  \nnext  \n      ", lp.View())
+
+	// erase without carriage return is a new line
+	lp.Update(LogMsg("abc\x1b[K"))
+	assert.Equal(t, "done  \nnext  \nabc   ", lp.View())
+
+	t.Run("reverse", func(t *testing.T) {
+		lp := NewLogPanel(10)
+		lp.Reverse = true
+		lp.Update(tapioca.ResizeMsg{Width: 6, Height: 2})
+		lp.Update(LogMsg("a\n50%"))
+		lp.Update(LogMsg("\r100%"))
+		assert.Equal(t, "100%  \na     ", lp.View())
+	})
+}
+
+func TestLogPanel_BatchMsg(t *testing.T) {
+	lp := NewLogPanel(10)
+	lp.Update(tapioca.ResizeMsg{Width: 3, Height: 2})
+	lp.Update(tapioca.BatchMsg{
+		LogMsg("a"),
+		LogMsg("b\nc"),
+		LogMsg("d"),
+	})
+	assert.Equal(t, "c  \nd  ", lp.View())
+
+	// not following when reading history
+	lp.Update(tapioca.ScrollTopMsg{})
+	lp.Update(tapioca.BatchMsg{LogMsg("e"), LogMsg("f")})
+	assert.Equal(t, "a  \nb  ", lp.View())
+	assert.Len(t, lp.impl.Entries(), 6)
+}
+
+func TestLogPanel_Burst(t *testing.T) {
+	lines := make([]string, 25)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i%7) + fmt.Sprint(i)
+	}
+	burst := LogMsg(strings.Join(lines, "\n"))
+
+	for _, budget := range []int{0, 30, 1000} {
+		for _, reverse := range []bool{false, true} {
+			// same as writing lines one by one
+			want := NewLogPanel(10)
+			got := NewLogPanel(10)
+			for _, lp := range []*LogPanel{want, got} {
+				lp.Reverse = reverse
+				lp.SetByteBudget(budget)
+				lp.Update(LogMsg("old\nlines"))
+			}
+			for _, l := range lines {
+				want.Update(LogMsg(l))
+			}
+			got.Update(burst)
+
+			msg := fmt.Sprintf("budget %d, reverse %v", budget, reverse)
+			assert.Equal(t, plainEntries(want), plainEntries(got), msg)
+			assert.Equal(t, want.sizes.GetAll(), got.sizes.GetAll(), msg)
+			assert.Equal(t, want.bytes, got.bytes, msg)
+		}
+	}
+}
+
+func plainEntries(lp *LogPanel) []string {
+	var ret []string
+	for _, e := range lp.impl.Entries() {
+		ret = append(ret, e.String())
+	}
+	return ret
+}
+
+// viewOnUpdate renders the panel after each message, like a model which
+// renders on the Tea goroutine
+type viewOnUpdate struct {
+	lp    *LogPanel
+	views int
+}
+
+func (m *viewOnUpdate) Init() tea.Cmd { return nil }
+func (m *viewOnUpdate) View() string  { return "" }
+func (m *viewOnUpdate) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	_, cmd := m.lp.UpdateInto(msg)
+	m.lp.View()
+	m.views++
+	return m, cmd
+}
+
+// run with -race: writers on other goroutines send messages, while buffer is
+// modified and rendered only on the Tea goroutine
+func TestLogPanel_ConcurrentWriters(t *testing.T) {
+	lp := NewLogPanel(100)
+	m := &viewOnUpdate{lp: lp}
+	prog := tea.NewProgram(m, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	done := make(chan error)
+	go func() {
+		_, err := prog.Run()
+		done <- err
+	}()
+	prog.Send(tapioca.ResizeMsg{Width: 20, Height: 5})
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := lp.CreateWriter(prog.Send, nil)
+			for j := range 10 {
+				fmt.Fprintf(w, "writer %d line %d\n", i, j)
+			}
+		}()
+	}
+	wg.Wait()
+	prog.Quit()
+	assert.NoError(t, <-done)
+
+	assert.Len(t, lp.impl.Entries(), 40)
+	assert.GreaterOrEqual(t, m.views, 41)
+}
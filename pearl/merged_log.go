@@ -0,0 +1,236 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// MergedLog displays log messages from multiple sources in chronological
+// order, instead of arrival order.
+//
+// Each message has a timestamp, messages are inserted by timestamp, and
+// messages with same timestamp are kept in arrival order. When there are more
+// than size messages, the oldest (by timestamp) ones are dropped.
+//
+// You must create MergedLog with NewMergedLog().
+type MergedLog struct {
+	id    int64
+	size  int
+	items []mergedLine // sorted by time
+	impl  *BufferedBlock
+
+	// Clock is used to stamp messages without timestamp. Default to
+	// [tapioca.SystemClock].
+	Clock tapioca.Clock
+}
+
+type mergedLine struct {
+	t     time.Time
+	entry *tapioca.Entry
+}
+
+// MergedLogMsg denotes some timestamped log messages are written to MergedLog.
+type MergedLogMsg struct {
+	id    int64
+	times []time.Time
+	lines []string
+}
+
+// NewMergedLog creates a new MergedLog which keeps at most size messages.
+func NewMergedLog(size int) *MergedLog {
+	if size < 10 {
+		size = 10
+	}
+	ret := &MergedLog{
+		id:    tapioca.NewID(),
+		size:  size,
+		impl:  NewBufferedBlock(size, false, true),
+		Clock: tapioca.SystemClock,
+	}
+	ret.impl.SetFollow(true)
+	return ret
+}
+
+// ScrollController returns the scroll controller of the log.
+func (m *MergedLog) ScrollController() tapioca.ScrollController {
+	return m.impl
+}
+
+// SetFollow enables or disables following new messages, see
+// [BufferedBlock.SetFollow]. Default to true.
+//
+// You should use it only when you are handling an event message.
+func (m *MergedLog) SetFollow(enable bool) {
+	m.impl.SetFollow(enable)
+}
+
+// Add inserts a message with timestamp t.
+//
+// You should use it only when you are handling an event message.
+func (m *MergedLog) Add(t time.Time, line string) {
+	if m.insert(t, line) {
+		m.rebuild()
+	}
+}
+
+// insert inserts a message into m.items. Newest message is also appended to
+// the underlying buffer, otherwise it reports the buffer has to be rebuilt.
+func (m *MergedLog) insert(t time.Time, line string) (dirty bool) {
+	// upper bound, so messages with same timestamp are kept in arrival order
+	idx := sort.Search(len(m.items), func(i int) bool {
+		return m.items[i].t.After(t)
+	})
+	if idx == 0 && len(m.items) >= m.size {
+		// older than everything in a full buffer, dropped immediately
+		return false
+	}
+
+	item := mergedLine{t: t, entry: tapioca.NewEntry(line)}
+	if idx == len(m.items) {
+		// fast path: newest message, the underlying buffer drops the oldest
+		// one as we do
+		m.items = append(m.items, item)
+		if len(m.items) > m.size {
+			m.items = m.items[1:]
+		}
+		m.impl.AppendEntry(item.entry)
+		return false
+	}
+
+	m.items = append(m.items, mergedLine{})
+	copy(m.items[idx+1:], m.items[idx:])
+	m.items[idx] = item
+	if len(m.items) > m.size {
+		m.items = m.items[1:]
+	}
+	return true
+}
+
+// rebuild refills the underlying buffer with m.items.
+func (m *MergedLog) rebuild() {
+	m.impl.entries.Reset()
+	for _, i := range m.items {
+		m.impl.entries.Append(i.entry)
+	}
+	m.impl.recomputeCachedInfo()
+}
+
+// Sender returns a function that sends a MergedLogMsg to add a message with
+// timestamp.
+func (m *MergedLog) Sender(send func(tea.Msg)) func(t time.Time, line string) {
+	return func(t time.Time, line string) {
+		send(MergedLogMsg{id: m.id, times: []time.Time{t}, lines: []string{line}})
+	}
+}
+
+// CreateWriter returns an io.Writer that writes log messages to the MergedLog,
+// like [LogPanel.CreateWriter]. You should create one writer per source.
+//
+// Timestamp of each line is extracted by parse. Lines which parse fails, like
+// a line of stack trace, use timestamp of previous line written by same
+// writer, or current time if there's none.
+func (m *MergedLog) CreateWriter(send func(tea.Msg), parse func(line string) (time.Time, bool)) io.Writer {
+	if send == nil {
+		panic("send is nil")
+	}
+	if parse == nil {
+		panic("parse is nil")
+	}
+	return &mergedWriterImpl{
+		m:     m,
+		send:  send,
+		parse: parse,
+	}
+}
+
+type mergedWriterImpl struct {
+	m     *MergedLog
+	send  func(tea.Msg)
+	parse func(string) (time.Time, bool)
+	last  time.Time
+	lock  sync.Mutex
+}
+
+func (w *mergedWriterImpl) Write(p []byte) (int, error) {
+	lines := bytes.Split(bytes.TrimRight(p, "\n"), []byte{'\n'})
+	msg := MergedLogMsg{
+		id:    w.m.id,
+		times: make([]time.Time, len(lines)),
+		lines: make([]string, len(lines)),
+	}
+
+	w.lock.Lock()
+	for i, l := range lines {
+		line := string(l)
+		t, ok := w.parse(line)
+		if !ok {
+			t = w.last
+			if t.IsZero() {
+				t = w.m.Clock.Now()
+			}
+		}
+		w.last = t
+		msg.times[i] = t
+		msg.lines[i] = line
+	}
+	w.lock.Unlock()
+
+	w.send(msg)
+	return len(p), nil
+}
+
+func (m *MergedLog) Init() tea.Cmd { return nil }
+
+func (m *MergedLog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *MergedLog instead of tea.Model.
+func (m *MergedLog) UpdateInto(msg tea.Msg) (*MergedLog, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case MergedLogMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+		follow := m.impl.following()
+		// rebuild once per message, not once per out-of-order line
+		dirty := false
+		for i := range msg.lines {
+			if m.insert(msg.times[i], msg.lines[i]) {
+				dirty = true
+			}
+		}
+		if dirty {
+			m.rebuild()
+		}
+		if follow {
+			m.impl.ScrollToBottom()
+		}
+	case tea.WindowSizeMsg:
+		return m.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		follow := m.impl.following()
+		m.impl, cmd = m.impl.UpdateInto(msg)
+		if follow {
+			m.impl.ScrollToBottom()
+		}
+	default:
+		m.impl, cmd = m.impl.UpdateInto(msg)
+	}
+	return m, cmd
+}
+
+func (m *MergedLog) View() string {
+	return m.impl.View()
+}
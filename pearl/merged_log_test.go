@@ -0,0 +1,157 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func mergedLines(m *MergedLog) []string {
+	ret := make([]string, 0, len(m.impl.Entries()))
+	for _, e := range m.impl.Entries() {
+		ret = append(ret, e.String())
+	}
+	return ret
+}
+
+func TestMergedLog_Add(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+
+	t.Run("chronological order", func(t *testing.T) {
+		m := NewMergedLog(10)
+		m.Add(at(1), "a1")
+		m.Add(at(3), "a3")
+		m.Add(at(2), "b2")
+		m.Add(at(0), "b0")
+		m.Add(at(2), "c2")
+		assert.Equal(t, []string{"b0", "a1", "b2", "c2", "a3"}, mergedLines(m))
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		m := NewMergedLog(10)
+		for i := range 10 {
+			m.Add(at(i*2), fmt.Sprint(i*2))
+		}
+		m.Add(at(-1), "too old")
+		assert.Equal(t, 10, len(mergedLines(m)))
+		assert.Equal(t, "0", mergedLines(m)[0])
+
+		m.Add(at(5), "5")
+		assert.Equal(t, []string{"2", "4", "5", "6", "8", "10", "12", "14", "16", "18"}, mergedLines(m))
+
+		m.Add(at(100), "100")
+		assert.Equal(t, []string{"4", "5", "6", "8", "10", "12", "14", "16", "18", "100"}, mergedLines(m))
+	})
+}
+
+func TestMergedLog_Batch(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+	batch := func(m *MergedLog, secs []int, lines ...string) {
+		msg := MergedLogMsg{id: m.id, lines: lines}
+		for _, s := range secs {
+			msg.times = append(msg.times, at(s))
+		}
+		m.Update(msg)
+	}
+
+	t.Run("out of order", func(t *testing.T) {
+		m := NewMergedLog(10)
+		batch(m, []int{2, 4}, "a2", "a4")
+		batch(m, []int{3, 1, 5, 3, 0}, "b3", "b1", "b5", "c3", "b0")
+		assert.Equal(t, []string{"b0", "b1", "a2", "b3", "c3", "a4", "b5"}, mergedLines(m))
+	})
+
+	t.Run("same as Add", func(t *testing.T) {
+		secs := []int{7, 3, 12, 1, 9, 9, 0, 15, 4, 11, 2, 14, 6, 13}
+		lines := make([]string, len(secs))
+		for i, s := range secs {
+			lines[i] = fmt.Sprint(s, "-", i)
+		}
+
+		m := NewMergedLog(10)
+		batch(m, secs, lines...)
+		expect := NewMergedLog(10)
+		for i, s := range secs {
+			expect.Add(at(s), lines[i])
+		}
+		assert.Equal(t, mergedLines(expect), mergedLines(m))
+		assert.Equal(t, 10, len(mergedLines(m)))
+	})
+}
+
+func TestMergedLog_Follow(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	send := func(m *MergedLog, sec int, line string) {
+		m.Update(MergedLogMsg{id: m.id, times: []time.Time{base.Add(time.Duration(sec) * time.Second)}, lines: []string{line}})
+	}
+
+	m := NewMergedLog(10)
+	m.Update(tapioca.ResizeMsg{Width: 2, Height: 2})
+	for i, l := range []string{"a", "b", "c"} {
+		send(m, i, l)
+	}
+	assert.Equal(t, "b \nc ", m.View())
+
+	// stays put while reading history
+	m.Update(tapioca.ScrollUpMsg(1))
+	send(m, 3, "d")
+	assert.Equal(t, "a \nb ", m.View())
+	m.Update(tapioca.ResizeMsg{Width: 2, Height: 1})
+	assert.Equal(t, "a ", m.View())
+
+	// follows again at bottom
+	m.Update(tapioca.ScrollBottomMsg{})
+	send(m, 4, "e")
+	assert.Equal(t, "e ", m.View())
+
+	m.SetFollow(false)
+	send(m, 5, "f")
+	assert.Equal(t, "e ", m.View())
+}
+
+func TestMergedLog_Writer(t *testing.T) {
+	parse := func(line string) (time.Time, bool) {
+		ts, _, ok := strings.Cut(line, " ")
+		if !ok {
+			return time.Time{}, false
+		}
+		ret, err := time.Parse("15:04:05", ts)
+		return ret, err == nil
+	}
+
+	m := NewMergedLog(10)
+	m.Clock = tapioca.NewFakeClock(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC))
+	send := func(msg tea.Msg) { m.Update(msg) }
+	w1 := m.CreateWriter(send, parse)
+	w2 := m.CreateWriter(send, parse)
+	m.Update(tapioca.ResizeMsg{Width: 20, Height: 5})
+
+	w1.Write([]byte("no timestamp\n"))
+	w1.Write([]byte("10:00:01 first\n10:00:05 second\n  stack trace\n"))
+	w2.Write([]byte("10:00:03 other\n"))
+
+	assert.Equal(t, []string{
+		"no timestamp",
+		"10:00:01 first",
+		"10:00:03 other",
+		"10:00:05 second",
+		"  stack trace",
+	}, mergedLines(m))
+
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  20,
+		Height: 3,
+		Model:  m,
+	}))
+}
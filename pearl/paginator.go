@@ -0,0 +1,169 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// Paginator shows one page at a time, with an indicator like "Page 2/7" at the
+// bottom row. It is useful for sectioned content like reports or wizards. The
+// indicator is not shown if there's no page.
+//
+// All pages are resized to the area above the indicator. Key messages are
+// passed to current page only, other messages are passed to all pages so they
+// can be updated while hidden.
+//
+// You must create Paginator with NewPaginator() or NewTextPaginator().
+type Paginator struct {
+	// IndicatorFormat formats the indicator with current page and number of
+	// pages, both start from 1. Default to "Page %d/%d".
+	IndicatorFormat string
+
+	id    int64
+	pages []tea.Model
+	cur   int
+	w, h  int
+}
+
+// PaginatorNextMsg is a message to show next page of a Paginator.
+type PaginatorNextMsg struct {
+	id int64
+}
+
+// PaginatorPrevMsg is a message to show previous page of a Paginator.
+type PaginatorPrevMsg struct {
+	id int64
+}
+
+// NewPaginator creates a Paginator showing pages, first page is shown.
+func NewPaginator(pages ...tea.Model) *Paginator {
+	return &Paginator{
+		IndicatorFormat: "Page %d/%d",
+		id:              tapioca.NewID(),
+		pages:           pages,
+	}
+}
+
+// NewTextPaginator creates a Paginator with each page showing lines of text in
+// a [Block].
+func NewTextPaginator(pages ...[]string) *Paginator {
+	models := make([]tea.Model, len(pages))
+	for i, lines := range pages {
+		b := NewBlock()
+		b.SetContent(lines...)
+		models[i] = b
+	}
+	return NewPaginator(models...)
+}
+
+// Page returns index of current page, starts from 0.
+func (p *Paginator) Page() int { return p.cur }
+
+// Pages returns number of pages.
+func (p *Paginator) Pages() int { return len(p.pages) }
+
+// SetPage shows idx-th page, it is clamped to valid range.
+//
+// You should use it only when you are handling an event message.
+func (p *Paginator) SetPage(idx int) {
+	p.cur = max(0, min(idx, len(p.pages)-1))
+}
+
+// NextPage shows next page, it stays at last page.
+//
+// You should use it only when you are handling an event message.
+func (p *Paginator) NextPage() { p.SetPage(p.cur + 1) }
+
+// PrevPage shows previous page, it stays at first page.
+//
+// You should use it only when you are handling an event message.
+func (p *Paginator) PrevPage() { p.SetPage(p.cur - 1) }
+
+// Navigator returns functions that send PaginatorNextMsg and PaginatorPrevMsg.
+func (p *Paginator) Navigator(send func(tea.Msg)) (next, prev func()) {
+	next = func() { send(PaginatorNextMsg{id: p.id}) }
+	prev = func() { send(PaginatorPrevMsg{id: p.id}) }
+	return
+}
+
+func (p *Paginator) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(p.pages))
+	for i, page := range p.pages {
+		cmds[i] = page.Init()
+	}
+	return tea.Batch(cmds...)
+}
+
+func (p *Paginator) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return p.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *Paginator instead of
+// tea.Model.
+func (p *Paginator) UpdateInto(msg tea.Msg) (*Paginator, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return p.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		p.w, p.h = msg.Width, msg.Height
+		return p, p.updateAll(tapioca.ResizeMsg{Width: p.w, Height: max(0, p.h-1)})
+	case PaginatorNextMsg:
+		if msg.id == p.id {
+			p.NextPage()
+		}
+	case PaginatorPrevMsg:
+		if msg.id == p.id {
+			p.PrevPage()
+		}
+	case tea.KeyMsg:
+		if len(p.pages) == 0 {
+			return p, nil
+		}
+		var cmd tea.Cmd
+		p.pages[p.cur], cmd = p.pages[p.cur].Update(msg)
+		return p, cmd
+	default:
+		return p, p.updateAll(msg)
+	}
+	return p, nil
+}
+
+// updateAll passes msg to all pages
+func (p *Paginator) updateAll(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+	for i, page := range p.pages {
+		var cmd tea.Cmd
+		p.pages[i], cmd = page.Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (p *Paginator) View() string {
+	if p.w <= 0 || p.h <= 0 {
+		return ""
+	}
+
+	if len(p.pages) == 0 {
+		lines := make([]string, p.h)
+		for i := range lines {
+			lines[i] = tapioca.BlankLine(p.w)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	indicator := centerIn(tapioca.NewEntry(fmt.Sprintf(p.IndicatorFormat, p.cur+1, len(p.pages))), p.w)
+	if p.h == 1 {
+		return indicator
+	}
+	return p.pages[p.cur].View() + "\n" + indicator
+}
@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginator(t *testing.T) {
+	cases := []struct {
+		width, height int
+	}{
+		{1, 1},
+		{2, 1},
+		{1, 2},
+		{5, 3},
+		{20, 10},
+	}
+
+	for _, c := range cases {
+		p := NewTextPaginator([]string{"first", "page"}, []string{"second page"})
+		assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  c.width,
+			Height: c.height,
+			Model:  p,
+		}), "%dx%d", c.width, c.height)
+
+		assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  c.width,
+			Height: c.height,
+			Model:  NewPaginator(),
+		}), "%dx%d empty", c.width, c.height)
+	}
+}
+
+func TestPaginator_Navigate(t *testing.T) {
+	p := NewTextPaginator([]string{"a", "b"}, []string{"c"}, []string{"d"})
+	p.Update(tapioca.ResizeMsg{Width: 10, Height: 3})
+	assert.Equal(t, "a         \nb         \n Page 1/3 ", p.View())
+
+	var msgs []tea.Msg
+	next, prev := p.Navigator(func(m tea.Msg) { msgs = append(msgs, m) })
+	next()
+	prev()
+
+	p.Update(msgs[0])
+	assert.Equal(t, 1, p.Page())
+	assert.Equal(t, "c         \n          \n Page 2/3 ", p.View())
+	p.Update(msgs[1])
+	assert.Equal(t, 0, p.Page())
+
+	// clamped
+	p.PrevPage()
+	assert.Equal(t, 0, p.Page())
+	p.SetPage(10)
+	assert.Equal(t, 2, p.Page())
+	p.NextPage()
+	assert.Equal(t, 2, p.Page())
+
+	// messages for other paginators are ignored
+	other, _ := NewPaginator().Navigator(func(m tea.Msg) { p.Update(m) })
+	p.SetPage(0)
+	other()
+	assert.Equal(t, 0, p.Page())
+
+	p.IndicatorFormat = "%d of %d"
+	assert.Equal(t, "a         \nb         \n  1 of 3  ", p.View())
+}
+
+func TestPaginator_PassMessages(t *testing.T) {
+	b1, b2 := NewBlock(), NewBlock()
+	p := NewPaginator(b1, b2)
+	p.Update(tapioca.ResizeMsg{Width: 8, Height: 2})
+	assert.Equal(t, 8, b2.Width())
+	assert.Equal(t, 1, b2.Height())
+
+	// hidden page is updated too
+	p.Update(BlockSetContentMsg{id: b2.id, data: []string{"xyz"}})
+	p.NextPage()
+	assert.Equal(t, "xyz     \nPage 2/2", p.View())
+}
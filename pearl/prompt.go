@@ -0,0 +1,187 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// Prompt is a minimal single-line input component, showing a label followed by
+// an editable buffer. It is designed for short inputs like search query or
+// filter, not for general text editing.
+//
+// Prompt handles tea.KeyMsg only when focused, which is not by default. When
+// user presses enter, it emits a [PromptSubmitMsg]. Long input is scrolled
+// horizontally to keep the cursor visible.
+//
+// Supported keys:
+//   - printable characters: insert at cursor
+//   - backspace, delete: remove character before/at cursor
+//   - left, right, home (ctrl+a), end (ctrl+e): move cursor
+//   - ctrl+u: clear the buffer
+//   - enter: submit
+type Prompt struct {
+	id      int64
+	label   *tapioca.Entry
+	buf     []rune
+	cursor  int // rune index in buf
+	offset  int // display column of buf where the viewport starts
+	focused bool
+	w, h    int
+}
+
+// PromptSubmitMsg is emitted when user presses enter in a focused Prompt.
+type PromptSubmitMsg struct {
+	ID    int64
+	Value string
+}
+
+// NewPrompt creates a new Prompt with the label. The label can be styled.
+func NewPrompt(label string) *Prompt {
+	return &Prompt{
+		id:    tapioca.NewID(),
+		label: tapioca.NewEntry(label),
+	}
+}
+
+// ID returns the id of the prompt, which is also the ID in PromptSubmitMsg.
+func (p *Prompt) ID() int64 { return p.id }
+
+// Focus makes the prompt handle key messages.
+func (p *Prompt) Focus() { p.focused = true }
+
+// Blur makes the prompt ignore key messages.
+func (p *Prompt) Blur() { p.focused = false }
+
+// Focused reports whether the prompt handles key messages.
+func (p *Prompt) Focused() bool { return p.focused }
+
+// Value returns current content of the buffer.
+func (p *Prompt) Value() string { return string(p.buf) }
+
+// SetValue replaces the buffer with v and moves cursor to the end.
+func (p *Prompt) SetValue(v string) {
+	p.buf = []rune(v)
+	p.cursor = len(p.buf)
+}
+
+func (p *Prompt) Init() tea.Cmd { return nil }
+
+func (p *Prompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return p.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *Prompt instead of tea.Model.
+func (p *Prompt) UpdateInto(msg tea.Msg) (*Prompt, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.w, p.h = msg.Width, msg.Height
+	case tapioca.ResizeMsg:
+		p.w, p.h = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if !p.focused {
+			return p, nil
+		}
+		return p, p.handleKey(msg)
+	}
+	return p, nil
+}
+
+func (p *Prompt) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		ret := PromptSubmitMsg{ID: p.id, Value: p.Value()}
+		return func() tea.Msg { return ret }
+	case tea.KeyBackspace:
+		if p.cursor > 0 {
+			p.buf = append(p.buf[:p.cursor-1], p.buf[p.cursor:]...)
+			p.cursor--
+		}
+	case tea.KeyDelete:
+		if p.cursor < len(p.buf) {
+			p.buf = append(p.buf[:p.cursor], p.buf[p.cursor+1:]...)
+		}
+	case tea.KeyLeft:
+		p.cursor = max(0, p.cursor-1)
+	case tea.KeyRight:
+		p.cursor = min(len(p.buf), p.cursor+1)
+	case tea.KeyHome, tea.KeyCtrlA:
+		p.cursor = 0
+	case tea.KeyEnd, tea.KeyCtrlE:
+		p.cursor = len(p.buf)
+	case tea.KeyCtrlU:
+		p.buf = p.buf[:0]
+		p.cursor = 0
+	case tea.KeyRunes, tea.KeySpace:
+		runes := msg.Runes
+		if msg.Type == tea.KeySpace {
+			runes = []rune{' '}
+		}
+		buf := make([]rune, 0, len(p.buf)+len(runes))
+		buf = append(buf, p.buf[:p.cursor]...)
+		buf = append(buf, runes...)
+		buf = append(buf, p.buf[p.cursor:]...)
+		p.buf = buf
+		p.cursor += len(runes)
+	}
+	return nil
+}
+
+func (p *Prompt) View() string {
+	if p.w <= 0 || p.h <= 0 {
+		return ""
+	}
+
+	labelWidth := min(p.label.Width(), p.w)
+	avail := p.w - labelWidth
+
+	b := &strings.Builder{}
+	b.WriteString(p.label.StyledMove(0, labelWidth))
+	if avail > 0 {
+		b.WriteString(p.renderInput(avail))
+	}
+
+	blank := tapioca.BlankLine(p.w)
+	for i := 1; i < p.h; i++ {
+		b.WriteByte('\n')
+		b.WriteString(blank)
+	}
+	return b.String()
+}
+
+// renderInput renders the buffer in width columns, scrolled to show the cursor
+func (p *Prompt) renderInput(width int) string {
+	// the character under the cursor, a space is used if cursor is at the end
+	cur := " "
+	if p.cursor < len(p.buf) {
+		cur = string(p.buf[p.cursor])
+	}
+	before := string(p.buf[:p.cursor])
+	after := ""
+	if p.cursor < len(p.buf) {
+		after = string(p.buf[p.cursor+1:])
+	}
+
+	// keep the cursor visible
+	curStart := tapioca.NewPlainEntry(before).Width()
+	curEnd := curStart + tapioca.RuneWidth([]rune(cur)[0])
+	total := curEnd + tapioca.NewPlainEntry(after).Width()
+	p.offset = min(p.offset, max(0, total-width))
+	if curStart < p.offset {
+		p.offset = curStart
+	}
+	if curEnd > p.offset+width {
+		p.offset = curEnd - width
+	}
+
+	if p.focused {
+		cur = "\x1b[7m" + cur + "\x1b[27m"
+	}
+	e := tapioca.NewEntry(before + cur + after)
+	return e.StyledMove(p.offset, width)
+}
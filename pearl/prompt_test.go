@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func typeString(p *Prompt, s string) {
+	for _, r := range s {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestPrompt_Topping(t *testing.T) {
+	cases := []struct {
+		width, height int
+	}{
+		{1, 1},
+		{2, 1},
+		{1, 2},
+		{10, 3},
+		{30, 10},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%dx%d", c.width, c.height), func(t *testing.T) {
+			p := NewPrompt("> ")
+			p.Focus()
+			typeString(p, "hello 世界 this is a long input")
+
+			assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+				Width:  c.width,
+				Height: c.height,
+				Model:  p,
+			}))
+		})
+	}
+}
+
+func TestPrompt_Editing(t *testing.T) {
+	p := NewPrompt("> ")
+	p.Update(tapioca.ResizeMsg{Width: 10, Height: 1})
+
+	typeString(p, "abc")
+	assert.Equal(t, "", p.Value(), "blurred prompt should ignore keys")
+
+	p.Focus()
+	typeString(p, "abc")
+	assert.Equal(t, "abc", p.Value())
+	assert.Equal(t, "> abc\x1b[7m \x1b[0m    ", p.View())
+
+	p.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	p.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, "ac", p.Value())
+	assert.Equal(t, "> a\x1b[7mc\x1b[0m      ", p.View())
+
+	p.Update(tea.KeyMsg{Type: tea.KeySpace})
+	p.Update(tea.KeyMsg{Type: tea.KeyHome})
+	p.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	assert.Equal(t, " c", p.Value())
+
+	p.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	typeString(p, "d")
+	assert.Equal(t, " cd", p.Value())
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, PromptSubmitMsg{ID: p.ID(), Value: " cd"}, cmd())
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	assert.Equal(t, "", p.Value())
+}
+
+func TestPrompt_HorizontalScroll(t *testing.T) {
+	p := NewPrompt("> ")
+	p.Focus()
+	p.Update(tapioca.ResizeMsg{Width: 6, Height: 1})
+
+	typeString(p, "abcdef")
+	assert.Equal(t, "> def\x1b[7m \x1b[0m", p.View())
+
+	p.Update(tea.KeyMsg{Type: tea.KeyHome})
+	assert.Equal(t, "> \x1b[7ma\x1b[0mbcd", p.View())
+
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	typeString(p, "x")
+	assert.Equal(t, "> x\x1b[7m \x1b[0m  ", p.View())
+}
+
+func TestPrompt_FillRune(t *testing.T) {
+	defer func(r rune) { tapioca.FillRune = r }(tapioca.FillRune)
+	tapioca.FillRune = '.'
+
+	p := NewPrompt("> ")
+	p.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	_, blank, _ := strings.Cut(p.View(), "\n")
+	assert.Equal(t, "....", blank)
+}
@@ -57,6 +57,8 @@ func (s *Span) Setter(f func(tea.Msg)) func(string) {
 // type assertion.
 func (s *Span) UpdateInto(msg tea.Msg) (*Span, tea.Cmd) {
 	switch m := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.w, s.h = m.Width, m.Height
 	case tapioca.ResizeMsg:
 		s.w, s.h = m.Width, m.Height
 	case SpanSetContentMsg:
@@ -78,7 +80,7 @@ func (s *Span) View() string {
 	}
 
 	for i := len(lines); i < s.h; i++ {
-		lines = append(lines, strings.Repeat(" ", s.w))
+		lines = append(lines, tapioca.BlankLine(s.w))
 	}
 	return strings.Join(lines, "\n")
 }
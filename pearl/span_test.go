@@ -56,3 +56,20 @@ func TestSpan(t *testing.T) {
 		})
 	}
 }
+
+func TestSpan_WindowSizeMsg(t *testing.T) {
+	s := NewSpan()
+	s.SetContent("hello")
+	s.Update(tea.WindowSizeMsg{Width: 7, Height: 2})
+	assert.Equal(t, "hello  \n       ", s.View())
+}
+
+func TestSpan_FillRune(t *testing.T) {
+	defer func(r rune) { tapioca.FillRune = r }(tapioca.FillRune)
+	tapioca.FillRune = '.'
+
+	s := NewSpan()
+	s.SetContent("ab")
+	s.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	assert.Equal(t, "ab  \n....", s.View())
+}
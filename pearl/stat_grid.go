@@ -0,0 +1,176 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// Stat is a labeled value shown by [StatGrid]. Both can be styled.
+type Stat struct {
+	Label string
+	Value string
+}
+
+// StatGrid shows a row of stats, like a dashboard header. The width is evenly
+// distributed to stats, leading cells are 1 column wider if it cannot be
+// divided evenly.
+//
+// In each cell, the value is emphasized with ValueStyle and the label is shown
+// below it, both are centered and truncated with an ellipsis if too wide. They
+// are vertically centered if the component is higher than 2 rows, and only the
+// value is shown if it has only 1 row.
+type StatGrid struct {
+	// ValueStyle is SGR sequence applied to values, bold by default.
+	ValueStyle string
+
+	id     int64
+	labels []*tapioca.Entry
+	values []string
+	w, h   int
+}
+
+// StatGridSetMsg is a message to replace all stats of a StatGrid.
+type StatGridSetMsg struct {
+	id    int64
+	stats []Stat
+}
+
+// StatGridSetValueMsg is a message to update value of a stat in a StatGrid.
+type StatGridSetValueMsg struct {
+	id    int64
+	idx   int
+	value string
+}
+
+// NewStatGrid creates a StatGrid showing stats.
+func NewStatGrid(stats ...Stat) *StatGrid {
+	ret := &StatGrid{
+		ValueStyle: "\x1b[1m",
+		id:         tapioca.NewID(),
+	}
+	ret.SetStats(stats...)
+	return ret
+}
+
+// SetStats replaces all stats.
+//
+// You should use it only when you are handling an event message.
+func (g *StatGrid) SetStats(stats ...Stat) {
+	g.labels = make([]*tapioca.Entry, len(stats))
+	g.values = make([]string, len(stats))
+	for i, s := range stats {
+		g.labels[i] = tapioca.NewEntry(s.Label)
+		g.values[i] = s.Value
+	}
+}
+
+// SetValue updates value of idx-th stat, it does nothing if idx is out of
+// range.
+//
+// You should use it only when you are handling an event message.
+func (g *StatGrid) SetValue(idx int, value string) {
+	if idx < 0 || idx >= len(g.values) {
+		return
+	}
+	g.values[idx] = value
+}
+
+// Setter returns a function that sends a StatGridSetMsg to replace all stats.
+func (g *StatGrid) Setter(send func(tea.Msg)) func(...Stat) {
+	return func(stats ...Stat) {
+		send(StatGridSetMsg{id: g.id, stats: stats})
+	}
+}
+
+// ValueSetter returns a function that sends a StatGridSetValueMsg to update
+// value of a stat.
+func (g *StatGrid) ValueSetter(send func(tea.Msg)) func(idx int, value string) {
+	return func(idx int, value string) {
+		send(StatGridSetValueMsg{id: g.id, idx: idx, value: value})
+	}
+}
+
+func (g *StatGrid) Init() tea.Cmd { return nil }
+
+func (g *StatGrid) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return g.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *StatGrid instead of
+// tea.Model.
+func (g *StatGrid) UpdateInto(msg tea.Msg) (*StatGrid, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		g.w, g.h = msg.Width, msg.Height
+	case tapioca.ResizeMsg:
+		g.w, g.h = msg.Width, msg.Height
+	case StatGridSetMsg:
+		if msg.id == g.id {
+			g.SetStats(msg.stats...)
+		}
+	case StatGridSetValueMsg:
+		if msg.id == g.id {
+			g.SetValue(msg.idx, msg.value)
+		}
+	}
+	return g, nil
+}
+
+func (g *StatGrid) View() string {
+	if g.w <= 0 || g.h <= 0 {
+		return ""
+	}
+
+	lines := make([]string, g.h)
+	for i := range lines {
+		lines[i] = tapioca.BlankLine(g.w)
+	}
+	if len(g.values) == 0 {
+		return strings.Join(lines, "\n")
+	}
+
+	// value row, label row is below it
+	row := max(0, (g.h-2)/2)
+	value := &strings.Builder{}
+	label := &strings.Builder{}
+	for i, cw := range evenWidths(g.w, len(g.values)) {
+		v := tapioca.NewEntry(g.ValueStyle + g.values[i])
+		value.WriteString(centerIn(v, cw))
+		label.WriteString(centerIn(g.labels[i], cw))
+	}
+	lines[row] = value.String()
+	if row+1 < g.h {
+		lines[row+1] = label.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// evenWidths divides total columns into n cells, leading cells take the
+// remainder
+func evenWidths(total, n int) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = total / n
+		if i < total%n {
+			ret[i]++
+		}
+	}
+	return ret
+}
+
+// centerIn renders e centered in width columns, truncated if too wide
+func centerIn(e *tapioca.Entry, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if w := e.Width(); w <= width {
+		return e.StyledMove(-(width-w)/2, width)
+	}
+	return e.StyledTruncate(width, '…')
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatGrid(t *testing.T) {
+	cases := []struct {
+		width, height int
+	}{
+		{1, 1},
+		{2, 1},
+		{1, 2},
+		{3, 2},
+		{10, 3},
+		{31, 4},
+		{80, 5},
+	}
+
+	for _, c := range cases {
+		g := NewStatGrid(Stat{"CPU", "42%"}, Stat{"Memory", "1.2 GiB"}, Stat{"Requests", "12,345/s"})
+		assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+			Width:  c.width,
+			Height: c.height,
+			Model:  g,
+		}), "%dx%d", c.width, c.height)
+	}
+
+	assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  10,
+		Height: 3,
+		Model:  NewStatGrid(),
+	}))
+}
+
+func TestStatGrid_View(t *testing.T) {
+	g := NewStatGrid(Stat{"CPU", "42%"}, Stat{"Memory", "1.2 GiB"})
+	g.ValueStyle = ""
+	g.Update(tapioca.ResizeMsg{Width: 21, Height: 4})
+	assert.Equal(t, []string{
+		"                     ",
+		"    42%     1.2 GiB  ",
+		"    CPU      Memory  ",
+		"                     ",
+	}, strings.Split(g.View(), "\n"))
+
+	// truncated
+	g.Update(tapioca.ResizeMsg{Width: 11, Height: 2})
+	assert.Equal(t, []string{
+		" 42%  1.2 …",
+		" CPU  Memo…",
+	}, strings.Split(g.View(), "\n"))
+
+	// value only
+	g.Update(tapioca.ResizeMsg{Width: 11, Height: 1})
+	assert.Equal(t, " 42%  1.2 …", g.View())
+}
+
+func TestStatGrid_Setters(t *testing.T) {
+	g := NewStatGrid(Stat{"CPU", "42%"})
+	g.Update(tapioca.ResizeMsg{Width: 5, Height: 2})
+	assert.Equal(t, " \x1b[1m42%\x1b[0m \n CPU ", g.View())
+
+	var msgs []tea.Msg
+	send := func(m tea.Msg) { msgs = append(msgs, m) }
+	g.ValueSetter(send)(0, "7%")
+	g.ValueSetter(send)(1, "ignored")
+	g.Setter(send)(Stat{"A", "1"}, Stat{"B", "2"})
+	g.ValueSetter(send)(1, "3")
+
+	g.Update(msgs[0])
+	g.Update(msgs[1])
+	assert.Equal(t, " \x1b[1m7%\x1b[0m  \n CPU ", g.View())
+
+	g.Update(msgs[2])
+	g.Update(msgs[3])
+	assert.Equal(t, " \x1b[1m1\x1b[0m \x1b[1m3\x1b[0m \n A B ", g.View())
+
+	// messages for other grids are ignored
+	other := NewStatGrid(Stat{"X", "0"})
+	other.Update(msgs[2])
+	other.Update(tapioca.ResizeMsg{Width: 3, Height: 1})
+	assert.Equal(t, " \x1b[1m0\x1b[0m ", other.View())
+}
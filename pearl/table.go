@@ -0,0 +1,250 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// Table is a component that displays rows of cells in aligned columns, with an
+// optional header row which is always visible.
+//
+// Cells are styled strings (parsed by [tapioca.Entry]), column widths are
+// measured by display width, ANSI styles are ignored. If the table is wider
+// than the component, wider columns are shrunk first, and cells are truncated
+// to column width with styles preserved. Use [Table.SetColumns] to limit or
+// stretch some columns.
+//
+// Rows can be scrolled vertically. If minimum widths of columns do not fit,
+// the table can be scrolled horizontally too.
+//
+// Table supports [tapioca.BatchMsg], columns are recomputed once for all rows
+// in a batch.
+type Table struct {
+	id     int64
+	header []*tapioca.Entry
+	rows   [][]*tapioca.Entry
+	tapioca.Scrollable
+
+	// string placed between columns, default to a space
+	sep      *tapioca.Entry
+	w, h     int
+	colWidth []int
+	zebra    ZebraStyle
+	// sizing of columns, see SetColumns
+	columns []TableColumn
+	// width of a row, can be larger than w if minimum widths do not fit
+	fullWidth int
+}
+
+// NewTable creates a new Table. If header is empty, no header row is shown.
+func NewTable(header ...string) *Table {
+	ret := &Table{
+		id:     tapioca.NewID(),
+		header: toEntries(header),
+		sep:    tapioca.NewEntry(" "),
+	}
+	ret.Scrollable = tapioca.NewScrollable(
+		func() int { return max(ret.w, ret.fullWidth) },
+		func() int { return len(ret.rows) },
+	)
+	return ret
+}
+
+func toEntries(cells []string) []*tapioca.Entry {
+	ret := make([]*tapioca.Entry, len(cells))
+	for i, c := range cells {
+		ret[i] = tapioca.NewEntry(c)
+	}
+	return ret
+}
+
+// TableSetRowsMsg is a message to replace all rows of a Table.
+type TableSetRowsMsg struct {
+	id   int64
+	rows [][]string
+}
+
+// TableAppendRowMsg is a message to append a row to a Table.
+type TableAppendRowMsg struct {
+	id    int64
+	cells []string
+}
+
+// SetSeparator sets the string placed between columns. It can be styled.
+func (t *Table) SetSeparator(sep string) {
+	t.sep = tapioca.NewEntry(sep)
+	t.recomputeColumns()
+}
+
+// AppendRow adds a row of styled cells to the end of the table.
+//
+// You should use it only when you are handling an event message.
+func (t *Table) AppendRow(cells ...string) {
+	t.appendRow(cells)
+	t.recomputeColumns()
+}
+
+func (t *Table) appendRow(cells []string) {
+	t.rows = append(t.rows, toEntries(cells))
+}
+
+// SetRows replaces all rows of the table.
+//
+// You should use it only when you are handling an event message.
+func (t *Table) SetRows(rows ...[]string) {
+	t.setRows(rows)
+	t.recomputeColumns()
+	t.Clamp()
+}
+
+func (t *Table) setRows(rows [][]string) {
+	t.rows = make([][]*tapioca.Entry, len(rows))
+	for i, r := range rows {
+		t.rows[i] = toEntries(r)
+	}
+}
+
+// Setter returns a function that sends a TableSetRowsMsg to replace all rows.
+func (t *Table) Setter(send func(tea.Msg)) func(...[]string) {
+	return func(rows ...[]string) {
+		send(TableSetRowsMsg{id: t.id, rows: rows})
+	}
+}
+
+// Appender returns a function that sends a TableAppendRowMsg to append a row.
+func (t *Table) Appender(send func(tea.Msg)) func(...string) {
+	return func(cells ...string) {
+		send(TableAppendRowMsg{id: t.id, cells: cells})
+	}
+}
+
+func (t *Table) headerHeight() int {
+	if len(t.header) == 0 {
+		return 0
+	}
+	return 1
+}
+
+// recomputeColumns computes width of each column to fit the component width
+func (t *Table) recomputeColumns() {
+	cols := len(t.header)
+	for _, r := range t.rows {
+		cols = max(cols, len(r))
+	}
+
+	natural := make([]int, cols)
+	measure := func(cells []*tapioca.Entry) {
+		for i, c := range cells {
+			natural[i] = max(natural[i], c.Width())
+		}
+	}
+	measure(t.header)
+	for _, r := range t.rows {
+		measure(r)
+	}
+
+	sepWidth := t.sep.Width() * max(0, cols-1)
+	t.colWidth = layoutColumns(natural, t.columns, t.w-sepWidth)
+	t.fullWidth = sepWidth
+	for _, w := range t.colWidth {
+		t.fullWidth += w
+	}
+}
+
+func (t *Table) renderRow(cells []*tapioca.Entry) string {
+	buf := &strings.Builder{}
+	for i, w := range t.colWidth {
+		if i > 0 {
+			buf.WriteString(t.sep.StyledString())
+		}
+		if i < len(cells) {
+			buf.WriteString(cells[i].StyledMove(0, w))
+		} else {
+			buf.WriteString(strings.Repeat(" ", w))
+		}
+	}
+
+	// fit to component width
+	e := tapioca.NewEntry(buf.String())
+	if e.Width() == t.w && t.X() == 0 {
+		return buf.String()
+	}
+	return e.StyledMove(t.X(), t.w)
+}
+
+func (t *Table) Init() tea.Cmd { return nil }
+
+func (t *Table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return t.UpdateInto(msg)
+}
+
+// UpdateInto is identical to Update, but returns *Table instead of tea.Model.
+func (t *Table) UpdateInto(msg tea.Msg) (*Table, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return t.UpdateInto(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
+	case tapioca.ResizeMsg:
+		t.w, t.h = msg.Width, msg.Height
+		t.recomputeColumns()
+		t.HandleEvent(tapioca.ResizeMsg{
+			Width:  msg.Width,
+			Height: max(0, msg.Height-t.headerHeight()),
+		})
+	case TableSetRowsMsg:
+		if msg.id == t.id {
+			t.SetRows(msg.rows...)
+		}
+	case TableAppendRowMsg:
+		if msg.id == t.id {
+			t.AppendRow(msg.cells...)
+		}
+	case tapioca.BatchMsg:
+		for _, m := range msg {
+			switch m := m.(type) {
+			case TableSetRowsMsg:
+				if m.id == t.id {
+					t.setRows(m.rows)
+				}
+			case TableAppendRowMsg:
+				if m.id == t.id {
+					t.appendRow(m.cells)
+				}
+			default:
+				t.UpdateInto(m)
+			}
+		}
+		t.recomputeColumns()
+		t.Clamp()
+	default:
+		t.HandleEvent(msg)
+	}
+	return t, nil
+}
+
+func (t *Table) View() string {
+	if t.w <= 0 || t.h <= 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, t.h)
+	if t.headerHeight() > 0 {
+		lines = append(lines, t.renderRow(t.header))
+	}
+	for i := t.Y(); i < len(t.rows) && len(lines) < t.h; i++ {
+		line := t.renderRow(t.rows[i])
+		if i%2 == 1 && t.zebra.Background != "" {
+			line = stripe(line, t.zebra.Background)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < t.h {
+		lines = append(lines, tapioca.BlankLine(t.w))
+	}
+	return strings.Join(lines[:t.h], "\n")
+}
@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import "math"
+
+// TableColumn configures how width of a column in [Table] is computed. Zero
+// value is the default sizing: natural width (widest cell), shrunk if the
+// table does not fit.
+type TableColumn struct {
+	// MinWidth is the width the column never shrinks below, it is padded if
+	// cells are narrower. Values less than 1 mean 1.
+	MinWidth int
+	// MaxWidth limits the width of the column, cells are truncated if they
+	// are wider. Values less than 1 mean no limit.
+	MaxWidth int
+	// Flex is the weight of the column to share the space left after all
+	// columns get their natural width. Values less than 1 mean the column
+	// does not grow.
+	Flex int
+}
+
+// SetColumns configures sizing of columns in order, columns not configured use
+// zero TableColumn.
+//
+// Widths are computed in following steps:
+//
+//  1. Each column starts at its natural width, clamped by MinWidth and
+//     MaxWidth.
+//  2. If the table is too wide, widest columns are shrunk one column at a
+//     time, but not below their MinWidth.
+//  3. If there is space left, it is shared by columns with Flex in proportion
+//     to it, a column stops growing at its MaxWidth.
+//
+// If minimum widths still do not fit, the table can be scrolled horizontally.
+//
+// You should use it only when you are handling an event message.
+func (t *Table) SetColumns(cols ...TableColumn) {
+	t.columns = cols
+	t.recomputeColumns()
+	t.Clamp()
+}
+
+// layoutColumns computes width of each column from its natural width, so that
+// they fit avail if possible
+func layoutColumns(natural []int, cols []TableColumn, avail int) []int {
+	widths := make([]int, len(natural))
+	mins := make([]int, len(natural))
+	maxs := make([]int, len(natural))
+	flex := make([]int, len(natural))
+	total := 0
+	for i, w := range natural {
+		var c TableColumn
+		if i < len(cols) {
+			c = cols[i]
+		}
+		mins[i] = max(1, c.MinWidth)
+		maxs[i] = math.MaxInt
+		if c.MaxWidth > 0 {
+			maxs[i] = max(mins[i], c.MaxWidth)
+		}
+		flex[i] = max(0, c.Flex)
+
+		widths[i] = min(max(w, mins[i]), maxs[i])
+		total += widths[i]
+	}
+
+	// shrink widest columns first
+	for total > avail {
+		widest := -1
+		for i, w := range widths {
+			if w > mins[i] && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	growColumns(widths, maxs, flex, avail-total)
+	return widths
+}
+
+// growColumns shares extra space to columns by their flex weights, columns stop
+// at maxs
+func growColumns(widths, maxs, flex []int, extra int) {
+	for extra > 0 {
+		weight := 0
+		for i, f := range flex {
+			if widths[i] < maxs[i] {
+				weight += f
+			}
+		}
+		if weight == 0 {
+			return
+		}
+
+		given := 0
+		for i, f := range flex {
+			if f == 0 || widths[i] >= maxs[i] {
+				continue
+			}
+			add := min(extra*f/weight, maxs[i]-widths[i])
+			widths[i] += add
+			given += add
+		}
+		if given == 0 {
+			// less than one column for each, give the rest in order
+			for i, f := range flex {
+				if given < extra && f > 0 && widths[i] < maxs[i] {
+					widths[i]++
+					given++
+				}
+			}
+		}
+		extra -= given
+	}
+}
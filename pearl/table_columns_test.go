@@ -0,0 +1,124 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutColumns(t *testing.T) {
+	cases := []struct {
+		name    string
+		natural []int
+		cols    []TableColumn
+		avail   int
+		expect  []int
+	}{
+		{"natural", []int{3, 5}, nil, 10, []int{3, 5}},
+		{"shrink widest", []int{3, 8}, nil, 8, []int{3, 5}},
+		{"shrink evenly", []int{6, 6}, nil, 8, []int{4, 4}},
+		{"at least 1", []int{3, 3}, nil, 1, []int{1, 1}},
+		{"empty column", []int{0, 2}, nil, 5, []int{1, 2}},
+		{"min pads", []int{2, 2}, []TableColumn{{MinWidth: 5}}, 10, []int{5, 2}},
+		{"min protects", []int{6, 6}, []TableColumn{{MinWidth: 6}}, 8, []int{6, 2}},
+		{"mins overflow", []int{6, 6}, []TableColumn{{MinWidth: 5}, {MinWidth: 5}}, 8, []int{5, 5}},
+		{"max truncates", []int{10, 2}, []TableColumn{{MaxWidth: 4}}, 20, []int{4, 2}},
+		{"max below min", []int{10}, []TableColumn{{MinWidth: 5, MaxWidth: 3}}, 20, []int{5}},
+		{"flex takes rest", []int{3, 3}, []TableColumn{{}, {Flex: 1}}, 10, []int{3, 7}},
+		{"flex weights", []int{1, 1}, []TableColumn{{Flex: 1}, {Flex: 3}}, 10, []int{3, 7}},
+		{"flex remainder in order", []int{1, 1, 1}, []TableColumn{{Flex: 1}, {Flex: 1}, {Flex: 1}}, 5, []int{2, 2, 1}},
+		{"flex capped by max", []int{1, 1}, []TableColumn{{Flex: 1, MaxWidth: 3}, {Flex: 1}}, 10, []int{3, 7}},
+		{"all capped", []int{1, 1}, []TableColumn{{Flex: 1, MaxWidth: 2}, {Flex: 1, MaxWidth: 2}}, 10, []int{2, 2}},
+		{"flex does not grow when shrunk", []int{8, 8}, []TableColumn{{Flex: 1}}, 10, []int{5, 5}},
+		{"negative values ignored", []int{2, 2}, []TableColumn{{MinWidth: -1, MaxWidth: -1, Flex: -1}}, 10, []int{2, 2}},
+		{"more configs than columns", []int{2}, []TableColumn{{Flex: 1}, {MinWidth: 9}}, 5, []int{5}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expect, layoutColumns(c.natural, c.cols, c.avail))
+		})
+	}
+}
+
+func TestTable_SetColumns(t *testing.T) {
+	newTable := func(w int, cols ...TableColumn) *Table {
+		tbl := NewTable("id", "message", "st")
+		tbl.SetRows(
+			[]string{"1", "started", "\x1b[32mok\x1b[m"},
+			[]string{"22", "something went wrong", "\x1b[31mfail\x1b[m"},
+		)
+		tbl.SetColumns(cols...)
+		tbl.Update(tapioca.ResizeMsg{Width: w, Height: 3})
+		assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{Width: w, Height: 3, Model: tbl}))
+		return tbl
+	}
+
+	t.Run("flex column takes extra space", func(t *testing.T) {
+		tbl := newTable(34, TableColumn{}, TableColumn{Flex: 1})
+		assert.Equal(t, []int{2, 26, 4}, tbl.colWidth)
+		assert.Equal(t, strings.Join([]string{
+			"id message                    st  ",
+			"1  started                    \x1b[32mok\x1b[0m  ",
+			"22 something went wrong       \x1b[31mfail\x1b[0m",
+		}, "\n"), tbl.View())
+	})
+
+	t.Run("min width is kept when shrinking", func(t *testing.T) {
+		tbl := newTable(16, TableColumn{}, TableColumn{}, TableColumn{MinWidth: 4})
+		assert.Equal(t, []int{2, 8, 4}, tbl.colWidth)
+		assert.Equal(t, "22 somethin \x1b[31mfail\x1b[0m", strings.Split(tbl.View(), "\n")[2])
+	})
+
+	t.Run("max width truncates", func(t *testing.T) {
+		tbl := newTable(20, TableColumn{}, TableColumn{MaxWidth: 5})
+		assert.Equal(t, []int{2, 5, 4}, tbl.colWidth)
+		assert.Equal(t, "22 somet \x1b[31mfail\x1b[0m       ", strings.Split(tbl.View(), "\n")[2])
+	})
+
+	t.Run("minimums overflow scroll horizontally", func(t *testing.T) {
+		tbl := newTable(8, TableColumn{MinWidth: 2}, TableColumn{MinWidth: 7}, TableColumn{MinWidth: 4})
+		assert.Equal(t, []int{2, 7, 4}, tbl.colWidth)
+		assert.Equal(t, 15, tbl.ExtentH())
+		assert.Equal(t, "id messa\n1  start\n22 somet", tbl.View())
+
+		tbl.Update(tapioca.ScrollEndMsg{})
+		assert.Equal(t, 7, tbl.X())
+		assert.Equal(t, "age st  \nted \x1b[32mok\x1b[0m  \nthi \x1b[31mfail\x1b[0m", tbl.View())
+
+		// no longer scrollable once it fits
+		tbl.Update(tapioca.ResizeMsg{Width: 15, Height: 3})
+		assert.Equal(t, 0, tbl.X())
+		assert.Equal(t, 15, tbl.ExtentH())
+	})
+}
+
+func TestTable_SetColumnsTopping(t *testing.T) {
+	configs := [][]TableColumn{
+		nil,
+		{{Flex: 1}, {Flex: 2}},
+		{{MinWidth: 6}, {MaxWidth: 2, Flex: 1}},
+		{{MinWidth: 20}, {MinWidth: 20}},
+	}
+	for i, cols := range configs {
+		for w := 1; w <= 30; w += 7 {
+			t.Run(fmt.Sprintf("config %d width %d", i, w), func(t *testing.T) {
+				tbl := NewTable("name", "status")
+				tbl.SetColumns(cols...)
+				tbl.AppendRow("長い名前のジョブ", "\x1b[31mfailed\x1b[m")
+				tbl.AppendRow("job")
+				assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{Width: w, Height: 3, Model: tbl}))
+
+				tbl.Update(tapioca.ScrollEndMsg{})
+				assert.Empty(t, tapioca.IsThisTopping(tapioca.ToppingTestSpec{Width: w, Height: 3, Model: tbl}))
+			})
+		}
+	}
+}
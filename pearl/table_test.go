@@ -0,0 +1,187 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Topping(t *testing.T) {
+	cases := []struct {
+		width, height int
+	}{
+		{1, 1},
+		{2, 1},
+		{1, 2},
+		{2, 2},
+		{10, 3},
+		{3, 10},
+		{10, 30},
+		{30, 10},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%dx%d", c.width, c.height), func(t *testing.T) {
+			tbl := NewTable("name", "status")
+			appender := tbl.Appender(func(msg tea.Msg) { tbl.Update(msg) })
+			appender("job1", "\x1b[32mok\x1b[m")
+			appender("長い名前のジョブ", "\x1b[31mfailed\x1b[m")
+			appender("job3")
+
+			assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+				Width:  c.width,
+				Height: c.height,
+				Model:  tbl,
+			}))
+		})
+	}
+}
+
+func TestTable_View(t *testing.T) {
+	cases := []struct {
+		name     string
+		width    int
+		header   []string
+		rows     [][]string
+		expected []string
+	}{
+		{
+			name:   "natural width",
+			width:  14,
+			header: []string{"name", "st"},
+			rows: [][]string{
+				{"a", "\x1b[32mok\x1b[m"},
+				{"bbbbb", "\x1b[31mfail\x1b[m"},
+			},
+			expected: []string{
+				"name  st      ",
+				"a     \x1b[32mok\x1b[0m      ",
+				"bbbbb \x1b[31mfail\x1b[0m    ",
+			},
+		},
+		{
+			name:   "shrink widest column, keep style",
+			width:  8,
+			header: []string{"name", "st"},
+			rows: [][]string{
+				{"abcdefgh", "\x1b[31mfail\x1b[m"},
+			},
+			expected: []string{
+				"nam st  ",
+				"abc \x1b[31mfail\x1b[0m",
+			},
+		},
+		{
+			name:   "wide characters",
+			width:  7,
+			header: []string{"名", "x"},
+			rows: [][]string{
+				{"名前です", "y"},
+			},
+			expected: []string{
+				"名    x",
+				"名前  y",
+			},
+		},
+		{
+			name:  "no header, missing cells",
+			width: 5,
+			rows: [][]string{
+				{"a", "b"},
+				{"c"},
+			},
+			expected: []string{
+				"a b  ",
+				"c    ",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tbl := NewTable(c.header...)
+			tbl.SetRows(c.rows...)
+			tbl.Update(tapioca.ResizeMsg{Width: c.width, Height: len(c.expected)})
+			assert.Equal(t, strings.Join(c.expected, "\n"), tbl.View())
+		})
+	}
+}
+
+func TestTable_Scroll(t *testing.T) {
+	tbl := NewTable("h")
+	tbl.Setter(func(msg tea.Msg) { tbl.Update(msg) })([]string{"1"}, []string{"2"}, []string{"3"})
+	tbl.Update(tapioca.ResizeMsg{Width: 1, Height: 2})
+	assert.Equal(t, "h\n1", tbl.View())
+
+	tbl.Update(tapioca.ScrollBottomMsg{})
+	assert.Equal(t, "h\n3", tbl.View())
+}
+
+func TestTable_BatchMsg(t *testing.T) {
+	tbl := NewTable("k", "v")
+	other := NewTable()
+	tbl.Update(tapioca.ResizeMsg{Width: 6, Height: 4})
+
+	send := func(msg tea.Msg) { tbl.Update(msg) }
+	batch := tapioca.BatchMsg{}
+	collect := func(msg tea.Msg) { batch = append(batch, msg) }
+	tbl.Setter(collect)([]string{"a", "1"})
+	tbl.Appender(collect)("bbb", "2")
+	other.Appender(collect)("ignored")
+	tbl.Appender(collect)("c", "3")
+	send(batch)
+
+	assert.Equal(t, "k   v \na   1 \nbbb 2 \nc   3 ", tbl.View())
+}
+
+func TestTable_SetZebraStyle(t *testing.T) {
+	const bg = "\x1b[48;5;236m"
+	tbl := NewTable("h")
+	tbl.SetZebraStyle(ZebraStyle{Background: bg})
+	tbl.Update(tapioca.ResizeMsg{Width: 2, Height: 4})
+	tbl.SetRows([]string{"a"}, []string{"b"}, []string{"c"})
+	assert.Equal(t, "h \na \n"+bg+"b \x1b[0m\nc ", tbl.View())
+
+	// stripes follow rows when scrolled
+	tbl.Update(tapioca.ResizeMsg{Width: 2, Height: 3})
+	tbl.ScrollDown(1)
+	assert.Equal(t, "h \n"+bg+"b \x1b[0m\nc ", tbl.View())
+}
+
+func TestTable_ClampAfterSetRows(t *testing.T) {
+	tbl := NewTable("h")
+	tbl.SetRows([]string{"1"}, []string{"2"}, []string{"3"}, []string{"4"})
+	tbl.Update(tapioca.ResizeMsg{Width: 1, Height: 3})
+	tbl.Update(tapioca.ScrollBottomMsg{})
+	assert.Equal(t, 2, tbl.Y())
+
+	tbl.SetRows([]string{"5"})
+	assert.Equal(t, 0, tbl.Y())
+	assert.Equal(t, "h\n5\n ", tbl.View())
+
+	tbl.SetRows([]string{"1"}, []string{"2"}, []string{"3"}, []string{"4"})
+	tbl.Update(tapioca.ScrollBottomMsg{})
+	batch := tapioca.BatchMsg{}
+	tbl.Setter(func(msg tea.Msg) { batch = append(batch, msg) })([]string{"6"}, []string{"7"})
+	tbl.Update(batch)
+	assert.Equal(t, 0, tbl.Y())
+}
+
+func TestTable_FillRune(t *testing.T) {
+	defer func(r rune) { tapioca.FillRune = r }(tapioca.FillRune)
+	tapioca.FillRune = '.'
+
+	tbl := NewTable()
+	tbl.SetRows([]string{"ab"})
+	tbl.Update(tapioca.ResizeMsg{Width: 4, Height: 2})
+	lines := strings.Split(tbl.View(), "\n")
+	assert.Equal(t, []string{"ab  ", "...."}, lines)
+}
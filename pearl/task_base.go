@@ -6,7 +6,9 @@ package pearl
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
@@ -34,19 +36,41 @@ type taskInfo struct {
 	state    TaskState
 	progress float64
 	spinner  spinner.Model
+	// key-value pairs in order of first set
+	annotations [][2]string
 }
 
-func newTaskInfo(id, desc string) *taskInfo {
+// newTaskInfo creates a task, fps <= 0 uses default frame rate of the spinner
+func newTaskInfo(id, desc string, fps int) *taskInfo {
+	s := spinner.Dot
+	if fps > 0 {
+		s.FPS = time.Second / time.Duration(fps)
+	}
 	return &taskInfo{
 		id:       id,
 		desc:     desc,
 		state:    TaskPending,
 		progress: -1.0,
-		spinner:  spinner.New(spinner.WithSpinner(spinner.Dot)),
+		spinner:  spinner.New(spinner.WithSpinner(s)),
+	}
+}
+
+// setAnnotation adds or updates an annotation, empty value removes it
+func (i *taskInfo) setAnnotation(key, value string) {
+	idx := slices.IndexFunc(i.annotations, func(a [2]string) bool { return a[0] == key })
+	switch {
+	case idx < 0 && value != "":
+		i.annotations = append(i.annotations, [2]string{key, value})
+	case idx >= 0 && value == "":
+		i.annotations = slices.Delete(i.annotations, idx, idx+1)
+	case idx >= 0:
+		i.annotations[idx][1] = value
 	}
 }
 
-func (i *taskInfo) render() string {
+// render renders the task in a line, annotations are truncated to fit width.
+// sep is placed between progress, description and annotations.
+func (i *taskInfo) render(width int, sep string) string {
 	b := &strings.Builder{}
 	b.Grow(len(i.desc) + 20)
 	// icon (emoji)
@@ -83,23 +107,60 @@ func (i *taskInfo) render() string {
 		if i.progress > 1.0 {
 			i.progress = 1.0
 		}
-		fmt.Fprintf(b, "[%6.2f%%] ", i.progress*100.0)
+		fmt.Fprintf(b, "[%6.2f%%]", i.progress*100.0)
+		b.WriteString(sep)
 	}
 
 	// description
 	b.WriteString(i.desc)
 
-	return b.String()
+	if len(i.annotations) == 0 {
+		return b.String()
+	}
+	return i.appendAnnotations(b.String(), width, sep)
+}
+
+// appendAnnotations appends dim "key=value" pairs to line after sep, pairs are
+// separated by space
+func (i *taskInfo) appendAnnotations(line string, width int, sep string) string {
+	pairs := make([]string, len(i.annotations))
+	for idx, a := range i.annotations {
+		pairs[idx] = a[0] + "=" + a[1]
+	}
+
+	rest := width - tapioca.NewEntry(line).Width() - tapioca.DisplayWidth(sep)
+	if rest <= 0 {
+		return line
+	}
+	ann := tapioca.NewEntry(strings.Join(pairs, " "))
+	str := ann.StyledString()
+	if ann.Width() > rest {
+		str = ann.StyledTruncate(rest, '…')
+	}
+	return line + sep + "\x1b[2m" + str + "\x1b[0m"
 }
 
 // TaskList is a component that manages and displays a list of tasks with their states.
 //
 // You might send task message by your own, or use [TaskManager].
 type TaskList struct {
+	// SpinnerFPS is the frame rate of spinners of running tasks, 0 uses the
+	// default one (10). A slower spinner saves bandwidth on slow terminal.
+	// It applies to tasks added after it is set, and is ignored if the
+	// task list is driven by an animator.
+	SpinnerFPS int
+	// Separator is placed between parts of a task line: progress,
+	// description and annotations. It can be styled, default to a space.
+	Separator string
+
 	tasks map[string]*taskInfo
 	impl  *Block
 	id    int64
 
+	// if not nil, spinners are driven by it instead of their own ticks
+	anim       *tapioca.Animator
+	cancelAnim func()
+
 	// cached info
 	pendingTasks []string // indexes of pending tasks
 	runningTasks []string // indexes of running tasks
@@ -111,12 +172,19 @@ func (t *TaskList) ID() int64 { return t.id }
 // NewTaskList creates a new TaskList component.
 func NewTaskList() *TaskList {
 	return &TaskList{
-		id:    tapioca.NewID(),
-		tasks: make(map[string]*taskInfo),
-		impl:  NewBlock(),
+		Separator: " ",
+		id:        tapioca.NewID(),
+		tasks:     make(map[string]*taskInfo),
+		impl:      NewBlock(),
 	}
 }
 
+// SetAnimator makes spinners of running tasks driven by a, instead of their own
+// timers. It should be called before any task is running.
+func (t *TaskList) SetAnimator(a *tapioca.Animator) {
+	t.anim = a
+}
+
 // AddTaskMsg is a message to add a new task.
 //
 // If the id already exists, the task will not be added.
@@ -150,6 +218,17 @@ type UpdateTaskDescMsg struct {
 	Desc       string
 }
 
+// UpdateTaskAnnotationMsg is a message to set an annotation of a task, which
+// is shown after the description like "key=value". Empty Value removes it.
+//
+// If the id does not exist, the message will be ignored.
+type UpdateTaskAnnotationMsg struct {
+	TaskListID int64
+	ID         string
+	Key        string
+	Value      string
+}
+
 // RemoveTaskMsg is a message to remove a task.
 //
 // If the id does not exist, the message will be ignored.
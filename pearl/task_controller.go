@@ -14,6 +14,9 @@ import (
 // TaskController is used to control what info is shown for a task.
 type TaskController interface {
 	SetDesc(desc string)
+	// SetAnnotation attaches contextual data like "host=db1" to the task,
+	// which is shown dim after the description. Empty value removes it.
+	SetAnnotation(key, value string)
 	SetState(state TaskState, progress float64)
 	Done()
 	Fail()
@@ -30,6 +33,10 @@ func (tc *taskController) SetDesc(desc string) {
 	tc.send(UpdateTaskDescMsg{TaskListID: tc.tid, ID: tc.id, Desc: desc})
 }
 
+func (tc *taskController) SetAnnotation(key, value string) {
+	tc.send(UpdateTaskAnnotationMsg{TaskListID: tc.tid, ID: tc.id, Key: key, Value: value})
+}
+
 func (tc *taskController) SetState(state TaskState, progress float64) {
 	if !state.IsValid() {
 		return
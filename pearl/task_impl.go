@@ -6,6 +6,7 @@ package pearl
 
 import (
 	"slices"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,7 +17,7 @@ func (l *TaskList) addTask(id, desc string) {
 	if _, ok := l.tasks[id]; ok {
 		return
 	}
-	l.tasks[id] = newTaskInfo(id, desc)
+	l.tasks[id] = newTaskInfo(id, desc, l.SpinnerFPS)
 	l.pendingTasks = append(l.pendingTasks, id)
 }
 
@@ -30,6 +31,9 @@ func (l *TaskList) updateTaskState(id string, state TaskState, progress float64)
 		l.recomputeCache(task, state)
 		if state == TaskRunning {
 			ret = task.spinner.Tick
+			if l.anim != nil {
+				ret = l.subscribe()
+			}
 		}
 		task.state = state
 	}
@@ -39,6 +43,31 @@ func (l *TaskList) updateTaskState(id string, state TaskState, progress float64)
 	return
 }
 
+// subscribe to the animator if not yet
+func (l *TaskList) subscribe() (ret tea.Cmd) {
+	if l.cancelAnim == nil {
+		l.cancelAnim, ret = l.anim.Subscribe(l.onFrame)
+	}
+	return
+}
+
+func (l *TaskList) onFrame(now time.Time) {
+	if len(l.runningTasks) == 0 {
+		l.cancelAnim()
+		l.cancelAnim = nil
+		return
+	}
+
+	for _, id := range l.runningTasks {
+		if task, ok := l.tasks[id]; ok {
+			// TickMsg without ID is accepted by every spinner, the command
+			// for next tick is dropped as the animator handles it
+			task.spinner, _ = task.spinner.Update(spinner.TickMsg{Time: now})
+		}
+	}
+	l.recomputeEntries()
+}
+
 func (l *TaskList) recomputeCache(task *taskInfo, newState TaskState) {
 	l.removeFromCache(task.id, task.state)
 	l.addToCache(task.id, newState)
@@ -88,6 +117,15 @@ func (l *TaskList) updateTaskDesc(id, desc string) {
 	}
 }
 
+func (l *TaskList) updateTaskAnnotation(id, key, value string) {
+	task, ok := l.tasks[id]
+	if !ok {
+		return
+	}
+
+	task.setAnnotation(key, value)
+}
+
 func (l *TaskList) removeTask(id string) {
 	task, ok := l.tasks[id]
 	if !ok {
@@ -103,6 +141,8 @@ func (l *TaskList) Init() tea.Cmd { return nil }
 func (l *TaskList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return l.Update(tapioca.ResizeMsg{Width: msg.Width, Height: msg.Height})
 	case AddTaskMsg:
 		l.addTask(msg.ID, msg.Desc)
 		l.recomputeEntries()
@@ -115,6 +155,9 @@ func (l *TaskList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case UpdateTaskDescMsg:
 		l.updateTaskDesc(msg.ID, msg.Desc)
 		l.recomputeEntries()
+	case UpdateTaskAnnotationMsg:
+		l.updateTaskAnnotation(msg.ID, msg.Key, msg.Value)
+		l.recomputeEntries()
 	case RemoveTaskMsg:
 		l.removeTask(msg.ID)
 		l.recomputeEntries()
@@ -194,7 +237,7 @@ func (l *TaskList) recomputeEntries() {
 		if !ok {
 			continue
 		}
-		lines = append(lines, task.render())
+		lines = append(lines, task.render(l.impl.Width(), l.Separator))
 	}
 
 	rList := l.runningTasks[:min(rc, rHeight)]
@@ -203,7 +246,7 @@ func (l *TaskList) recomputeEntries() {
 		if !ok {
 			continue
 		}
-		lines = append(lines, task.render())
+		lines = append(lines, task.render(l.impl.Width(), l.Separator))
 	}
 
 	pList := l.pendingTasks[:min(pc, pHeight)]
@@ -212,7 +255,7 @@ func (l *TaskList) recomputeEntries() {
 		if !ok {
 			continue
 		}
-		lines = append(lines, task.render())
+		lines = append(lines, task.render(l.impl.Width(), l.Separator))
 	}
 
 	l.impl.SetContent(lines...)
@@ -6,7 +6,9 @@ package pearl
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/raohwork/huninn/tapioca"
@@ -47,3 +49,90 @@ func TestTaskList(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskList_WindowSizeMsg(t *testing.T) {
+	l := NewTaskList()
+	l.CreateManager(func(msg tea.Msg) { l.Update(msg) }).AddTask("task1", "")
+	l.Update(tea.WindowSizeMsg{Width: 10, Height: 2})
+	assert.Equal(t, 2, l.impl.Height())
+	assert.Equal(t, 10, l.impl.Width())
+}
+
+func TestTaskList_Animator(t *testing.T) {
+	anim := tapioca.NewAnimator(1000)
+	l := NewTaskList()
+	l.SetAnimator(anim)
+	root := anim.Wrap(l)
+	root.Update(tapioca.ResizeMsg{Width: 10, Height: 3})
+	root.Update(AddTaskMsg{TaskListID: l.ID(), ID: "a", Desc: "a"})
+	root.Update(AddTaskMsg{TaskListID: l.ID(), ID: "b", Desc: "b"})
+
+	_, start := root.Update(UpdateTaskStateMsg{TaskListID: l.ID(), ID: "a", State: TaskRunning, Progress: -1})
+	assert.NotNil(t, start, "should start the animator")
+	_, cmd := root.Update(UpdateTaskStateMsg{TaskListID: l.ID(), ID: "b", State: TaskRunning, Progress: -1})
+	assert.Nil(t, cmd, "should share the animator")
+	assert.Equal(t, 1, anim.Subscribers())
+
+	before := l.View()
+	_, next := root.Update(start())
+	assert.NotNil(t, next, "should schedule next frame")
+	assert.NotEqual(t, before, l.View(), "spinners should advance")
+
+	// stop after all tasks are finished
+	root.Update(UpdateTaskStateMsg{TaskListID: l.ID(), ID: "a", State: TaskDone})
+	root.Update(UpdateTaskStateMsg{TaskListID: l.ID(), ID: "b", State: TaskFailed})
+	_, next = root.Update(next())
+	assert.Nil(t, next)
+	assert.Equal(t, 0, anim.Subscribers())
+}
+
+func TestTaskList_SpinnerFPS(t *testing.T) {
+	l := NewTaskList()
+	l.Update(AddTaskMsg{TaskListID: l.ID(), ID: "default"})
+	l.SpinnerFPS = 2
+	l.Update(AddTaskMsg{TaskListID: l.ID(), ID: "slow"})
+
+	assert.Equal(t, time.Second/10, l.tasks["default"].spinner.Spinner.FPS)
+	assert.Equal(t, time.Second/2, l.tasks["slow"].spinner.Spinner.FPS)
+}
+
+func TestTaskList_Annotation(t *testing.T) {
+	l := NewTaskList()
+	l.Update(tapioca.ResizeMsg{Width: 25, Height: 1})
+	task := l.CreateManager(func(msg tea.Msg) { l.Update(msg) }).AddTask("build", "t")
+	task.SetAnnotation("host", "db1")
+	task.SetAnnotation("retry", "1")
+	task.SetAnnotation("retry", "2")
+	assert.Equal(t, "🕓 build \x1b[2mhost=db1 retry=2\x1b[0m", l.View())
+
+	// truncated to fit
+	task.SetAnnotation("user", "root")
+	assert.Equal(t, "🕓 build \x1b[2mhost=db1 retry=…\x1b[0m", l.View())
+	assert.Equal(t, "", tapioca.IsThisTopping(tapioca.ToppingTestSpec{
+		Width:  25,
+		Height: 1,
+		Model:  l,
+	}))
+
+	task.SetAnnotation("host", "")
+	task.SetAnnotation("retry", "")
+	assert.Equal(t, "🕓 build \x1b[2muser=root\x1b[0m       ", l.View())
+}
+
+func TestTaskList_Separator(t *testing.T) {
+	l := NewTaskList()
+	l.Separator = " | "
+	l.Update(tapioca.ResizeMsg{Width: 30, Height: 1})
+	task := l.CreateManager(func(msg tea.Msg) { l.Update(msg) }).AddTask("build", "t")
+	task.SetAnnotation("host", "db1")
+	assert.Equal(t, "🕓 build | \x1b[2mhost=db1\x1b[0m"+strings.Repeat(" ", 11), l.View())
+
+	task.SetState(TaskRunning, 0.5)
+	frame := l.tasks["t"].spinner.View()
+	assert.Equal(t, frame+" [ 50.00%] | build | host=db1", tapioca.NewEntry(l.tasks["t"].render(40, l.Separator)).String())
+
+	// separator takes room of annotations
+	task.SetState(TaskPending, 0)
+	assert.Equal(t, "🕓 build | \x1b[2mh…\x1b[0m", l.tasks["t"].render(13, l.Separator))
+	assert.Equal(t, "🕓 build", l.tasks["t"].render(11, l.Separator))
+}
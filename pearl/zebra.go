@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package pearl
+
+import "strings"
+
+// ZebraStyle stripes alternate rows with a background color for readability
+// of dense content. Entries are not modified, the background is applied to
+// whole rows when rendering.
+type ZebraStyle struct {
+	// Background is SGR sequence of the background of odd rows, like
+	// "\x1b[48;5;236m". Empty string disables striping.
+	Background string
+	// ByEntry stripes by entry instead of display row, so all rows of a
+	// wrapped entry have same background. Table ignores it.
+	ByEntry bool
+}
+
+// stripe fills line with background bg. Since the line might reset styles or
+// background, bg is applied again after every reset.
+func stripe(line, bg string) string {
+	r := strings.NewReplacer("\x1b[0m", "\x1b[0m"+bg, "\x1b[49m", "\x1b[49m"+bg)
+	return bg + r.Replace(line) + "\x1b[0m"
+}
+
+// SetZebraStyle stripes alternate rows of the component, see [ZebraStyle].
+// Blank rows are not striped.
+func (c *BufferedBlock) SetZebraStyle(z ZebraStyle) {
+	c.zebra = z
+}
+
+// applyZebra stripes lines of the viewport
+func (c *BufferedBlock) applyZebra(lines []string) {
+	for i, r := range c.rowEntries() {
+		if r.idx < 0 {
+			continue
+		}
+		n := r.line
+		if c.zebra.ByEntry {
+			n = r.nth
+		}
+		if n%2 == 1 {
+			lines[i] = stripe(lines[i], c.zebra.Background)
+		}
+	}
+}
+
+// SetZebraStyle stripes alternate rows of the table, the header is not
+// striped. See [ZebraStyle].
+func (t *Table) SetZebraStyle(z ZebraStyle) {
+	t.zebra = z
+}
@@ -0,0 +1,116 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Animator drives animated components with a single timer, instead of one timer
+// per component, so they are repainted together in same frame.
+//
+// Animated components subscribe to the animator, and are called back on every
+// frame. The timer stops when there's no subscriber, and restarts by next
+// subscription.
+//
+// Frames are delivered by [FrameMsg], which is handled by the model returned by
+// Wrap. So you must wrap your root model with it:
+//
+//	anim := tapioca.NewAnimator(10)
+//	tl := pearl.NewTaskList()
+//	tl.SetAnimator(anim)
+//	...
+//	p := tea.NewProgram(anim.Wrap(root))
+//
+// Animator is not thread-safe, use it only when handling messages.
+type Animator struct {
+	id       int64
+	interval time.Duration
+	subs     map[int64]func(time.Time)
+	running  bool
+}
+
+// FrameMsg is sent by the timer of an Animator on every frame.
+type FrameMsg struct {
+	id   int64
+	Time time.Time
+}
+
+// NewAnimator creates an Animator running at fps frames per second. fps less
+// than 1 is reset to 1.
+func NewAnimator(fps int) *Animator {
+	return &Animator{
+		id:       NewID(),
+		interval: time.Second / time.Duration(max(1, fps)),
+		subs:     map[int64]func(time.Time){},
+	}
+}
+
+// Subscribe registers f to be called with current time on every frame. It
+// returns a function to cancel the subscription, and a command to start the
+// timer, which is nil if it is running.
+func (a *Animator) Subscribe(f func(now time.Time)) (cancel func(), cmd tea.Cmd) {
+	id := NewID()
+	a.subs[id] = f
+	cancel = func() { delete(a.subs, id) }
+	if !a.running {
+		a.running = true
+		cmd = a.tick()
+	}
+	return
+}
+
+// Subscribers returns number of subscribers.
+func (a *Animator) Subscribers() int {
+	return len(a.subs)
+}
+
+func (a *Animator) tick() tea.Cmd {
+	return tea.Tick(a.interval, func(t time.Time) tea.Msg {
+		return FrameMsg{id: a.id, Time: t}
+	})
+}
+
+// Update handles FrameMsg of the animator, and returns the command for next
+// frame. Other messages are ignored.
+//
+// You do not need it if you are using Wrap.
+func (a *Animator) Update(msg tea.Msg) tea.Cmd {
+	m, ok := msg.(FrameMsg)
+	if !ok || m.id != a.id {
+		return nil
+	}
+
+	for _, f := range a.subs {
+		f(m.Time)
+	}
+	if len(a.subs) == 0 {
+		a.running = false
+		return nil
+	}
+	return a.tick()
+}
+
+// Wrap returns a model which delivers frames before passing messages to m.
+func (a *Animator) Wrap(m tea.Model) tea.Model {
+	return &animated{Model: m, a: a}
+}
+
+type animated struct {
+	tea.Model
+	a *Animator
+}
+
+func (m *animated) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if f, ok := msg.(FrameMsg); ok && f.id == m.a.id {
+		return m, m.a.Update(msg)
+	}
+
+	var cmd tea.Cmd
+	m.Model, cmd = m.Model.Update(msg)
+	return m, cmd
+}
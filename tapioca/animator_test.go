@@ -0,0 +1,61 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnimator(t *testing.T) {
+	a := NewAnimator(100)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var calls []string
+	cancelA, cmd := a.Subscribe(func(time.Time) { calls = append(calls, "a") })
+	assert.NotNil(t, cmd, "should start the timer")
+	_, cmd = a.Subscribe(func(time.Time) { calls = append(calls, "b") })
+	assert.Nil(t, cmd, "timer is running")
+	assert.Equal(t, 2, a.Subscribers())
+
+	// frame from other animator is ignored
+	assert.Nil(t, a.Update(FrameMsg{id: NewAnimator(1).id, Time: now}))
+	assert.Empty(t, calls)
+
+	assert.NotNil(t, a.Update(FrameMsg{id: a.id, Time: now}), "should schedule next frame")
+	assert.ElementsMatch(t, []string{"a", "b"}, calls)
+
+	cancelA()
+	calls = nil
+	a.Update(FrameMsg{id: a.id, Time: now})
+	assert.Equal(t, []string{"b"}, calls)
+}
+
+func TestAnimator_Stop(t *testing.T) {
+	a := NewAnimator(100)
+	cancel, _ := a.Subscribe(func(time.Time) {})
+	cancel()
+	assert.Nil(t, a.Update(FrameMsg{id: a.id}), "should stop without subscriber")
+
+	_, cmd := a.Subscribe(func(time.Time) {})
+	assert.NotNil(t, cmd, "should restart the timer")
+	msg := cmd()
+	assert.IsType(t, FrameMsg{}, msg)
+}
+
+func TestAnimator_Wrap(t *testing.T) {
+	a := NewAnimator(100)
+	frames := 0
+	a.Subscribe(func(time.Time) { frames++ })
+
+	r := &resizeRecorder{}
+	m := a.Wrap(r)
+	m.Update(FrameMsg{id: a.id})
+	assert.Equal(t, 1, frames)
+	m.Update(ResizeMsg{Width: 1, Height: 2})
+	assert.Equal(t, []string{"msg 1x2"}, r.calls, "other messages are forwarded")
+}
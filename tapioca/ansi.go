@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"regexp"
+	"strings"
+)
+
+var oscRegex = regexp.MustCompile(`^\x1b\]([^\x07\x1b]*)(\x07|\x1b\\)`)
+
+// OSC 8 hyperlink, see
+// https://gist.github.com/egmontkob/eb114294efbcd5adb1944c9f3cb5feda
+const oscLinkEnd = "\x1b]8;;\x1b\\"
+
+func oscLinkStart(link string) string {
+	return "\x1b]8;;" + link + "\x1b\\"
+}
+
+// parseOSC parses the OSC sequence at the beginning of s. n is length of the
+// sequence, 0 if s does not start with a complete OSC sequence. ok reports
+// whether it is a hyperlink, and link is its target (empty to close the link).
+func parseOSC(s string) (n int, link string, ok bool) {
+	m := oscRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, "", false
+	}
+
+	body, found := strings.CutPrefix(m[1], "8;")
+	if !found {
+		return len(m[0]), "", false
+	}
+	// params are ignored
+	_, link, _ = strings.Cut(body, ";")
+	return len(m[0]), link, true
+}
+
+// StripANSI removes ANSI escape sequences from s without building an Entry.
+//
+// The result is identical to NewEntry(s).String(). Besides SGR, escape
+// sequences like cursor movement, OSC hyperlinks and DCS strings are removed.
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiOtherRegex.ReplaceAllString(s, "")
+}
+
+// DisplayWidth returns the number of terminal columns s takes, ANSI escape
+// sequences are ignored, East Asian wide characters take 2 columns and
+// grapheme clusters like flags or emoji sequences are measured as a whole.
+//
+// It uses same rules as [Entry.Width], without building an Entry.
+func DisplayWidth(s string) int {
+	s = StripANSI(s)
+	ret := 0
+	var cur StyledRune
+	for i, r := range s {
+		if i > 0 && joinsCluster(cur, r) {
+			cur.Tail += string(r)
+			continue
+		}
+		if i > 0 {
+			ret += cur.width()
+		}
+		cur = StyledRune{Rune: r}
+	}
+	if s != "" {
+		ret += cur.width()
+	}
+	return ret
+}
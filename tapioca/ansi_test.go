@@ -0,0 +1,95 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripANSI(t *testing.T) {
+	t.Run("same as entry", func(t *testing.T) {
+		cases := []string{
+			"",
+			"plain text",
+			"\x1b[31mred\x1b[0m text",
+			"\x1b[1;4;38;5;208mstyled\x1b[m 中文",
+			"\x1b[2Jclear\x1b[1;1Hmove",
+		}
+		for _, c := range cases {
+			assert.Equal(t, NewEntry(c).String(), StripANSI(c), "input: %q", c)
+		}
+	})
+
+	t.Run("osc", func(t *testing.T) {
+		link := "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"
+		assert.Equal(t, "link", StripANSI(link))
+		assert.Equal(t, "title", StripANSI("\x1b]0;window\x07title"))
+	})
+}
+
+func TestDisplayWidth(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"中文", 4},
+		{"ａｂ", 4},
+		{"\x1b[31mred\x1b[0m 中", 6},
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"\x1b[31m", 0},
+		{"cafe\u0301", 4},
+		{"\U0001F1F9\U0001F1FC\U0001F1EF", 4},
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467", 2},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expect, DisplayWidth(c.input), "input: %q", c.input)
+	}
+
+	// agrees with Entry
+	for _, s := range []string{"ab你好cd", "\x1b[1;32mok\x1b[m done", "\x1b[2Jclear", "\u2764\ufe0f\U0001F44D\U0001F3FD"} {
+		assert.Equal(t, NewEntry(s).Width(), DisplayWidth(s), "input: %q", s)
+	}
+}
+
+func TestNewEntry_EscapeSequences(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{name: "cursor position", input: "a\x1b[1;1Hb"},
+		{name: "private mode", input: "a\x1b[?25lb\x1b[?25h"},
+		{name: "erase display", input: "a\x1b[2Jb"},
+		{name: "save cursor", input: "a\x1b7b\x1b8"},
+		{name: "keypad mode", input: "a\x1b=b\x1b>"},
+		{name: "reset", input: "\x1bca\x1bMb"},
+		{name: "charset", input: "a\x1b(Bb\x1b)0"},
+		{name: "osc with BEL", input: "a\x1b]0;title\x07b"},
+		{name: "osc with ST", input: "a\x1b]2;title\x1b\\b"},
+		{name: "dcs", input: "a\x1bP1$r0m\x1b\\b"},
+		{name: "apc", input: "a\x1b_Gf=24;AAAA\x1b\\b"},
+		{name: "stray ST", input: "a\x1b\\b"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEntry(c.input)
+			assert.Equal(t, "ab", e.String())
+			assert.Equal(t, 2, e.Width())
+			assert.Equal(t, "ab", StripANSI(c.input))
+			assert.Equal(t, "ab", NewEntry("").Redraw(c.input).String())
+		})
+	}
+
+	t.Run("styles are kept", func(t *testing.T) {
+		e := NewEntry("\x1b7\x1b[31ma\x1b[?25l\x1b]8;;https://example.com\x1b\\b\x1b]8;;\x1b\\\x1b8")
+		assert.Equal(t, "ab", e.String())
+		assert.Equal(t, "\x1b[31m", e.styledData[0].Style.String())
+		assert.Equal(t, "https://example.com", e.styledData[1].Link)
+	})
+}
@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides current time to time-based features, so they can be tested
+// with a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock is the default Clock, which uses time.Now().
+var SystemClock Clock = systemClock{}
+
+// FakeClock is a Clock which is controlled manually, mainly used in tests.
+//
+// It is safe for concurrent use.
+type FakeClock struct {
+	lock sync.Mutex
+	t    time.Time
+}
+
+// NewFakeClock creates a FakeClock which stops at t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{t: t}
+}
+
+// Now implements Clock.
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.t
+}
+
+// Set sets current time to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.t = t
+}
+
+// Advance moves current time forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.t = c.t.Add(d)
+}
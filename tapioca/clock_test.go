@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	begin := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := NewFakeClock(begin)
+	assert.Equal(t, begin, c.Now())
+
+	c.Advance(time.Minute)
+	assert.Equal(t, begin.Add(time.Minute), c.Now())
+
+	c.Set(begin)
+	assert.Equal(t, begin, c.Now())
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	got := SystemClock.Now()
+	assert.False(t, got.Before(before))
+}
@@ -0,0 +1,193 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ColorLevel is the color depth a terminal supports.
+type ColorLevel int
+
+const (
+	// TrueColor supports 24-bit RGB colors like "38;2;r;g;b".
+	TrueColor ColorLevel = iota
+	// ANSI256 supports 256 colors like "38;5;n".
+	ANSI256
+	// ANSI16 supports basic and bright colors like "31" or "91".
+	ANSI16
+	// NoColor does not support colors.
+	NoColor
+)
+
+// Colors is the color depth of the terminal. Colors deeper than it are
+// converted to nearest ones when rendering, others are passed through. Default
+// to TrueColor, which renders all colors as is.
+//
+// Set it before rendering anything, rendered results might be cached.
+var Colors = TrueColor
+
+// palette16 is RGB of the 16 basic colors in xterm
+var palette16 = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels is the intensity of each step in the 6x6x6 color cube
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// colorFields splits a color parameter like "38;5;n", "48;2;r;g;b" or its
+// colon form "38:2::r:g:b" into fields after the kind, with colorspace id of
+// the colon form removed. It returns nil for basic colors like "31".
+func colorFields(param string) []string {
+	i := strings.IndexAny(param, ";:")
+	if i < 0 {
+		return nil
+	}
+	parts := strings.Split(param[i+1:], param[i:i+1])
+	if param[i] == ':' && len(parts) == 5 && parts[0] == "2" {
+		// 38:2:<colorspace>:r:g:b
+		parts = append(parts[:1], parts[2:]...)
+	}
+	return parts
+}
+
+// colorDepth returns the color depth needed by a color parameter like "31",
+// "38;5;n" or "48;2;r;g;b", in semicolon or colon form
+func colorDepth(param string) ColorLevel {
+	fields := colorFields(param)
+	switch {
+	case len(fields) == 0:
+		return ANSI16
+	case len(fields) > 1 && fields[0] == "5":
+		return ANSI256
+	default:
+		return TrueColor
+	}
+}
+
+// degradeColor converts color parameter to fit level. kind is "38", "48" or
+// "58" for foreground, background and underline. It returns empty string if
+// the color cannot be shown.
+func degradeColor(param, kind string, level ColorLevel) string {
+	if param == "" || colorDepth(param) >= level {
+		return param
+	}
+	if level == NoColor {
+		return ""
+	}
+
+	rgb, ok := colorRGB(param)
+	if !ok {
+		return ""
+	}
+	if level == ANSI256 {
+		return kind + ";5;" + strconv.Itoa(nearest256(rgb))
+	}
+
+	// ANSI16
+	n := nearest16(rgb)
+	switch kind {
+	case "38":
+		if n < 8 {
+			return strconv.Itoa(30 + n)
+		}
+		return strconv.Itoa(90 + n - 8)
+	case "48":
+		if n < 8 {
+			return strconv.Itoa(40 + n)
+		}
+		return strconv.Itoa(100 + n - 8)
+	}
+	// no underline color in 16 colors
+	return ""
+}
+
+// colorRGB parses extended color parameter like "38;5;n" or "38;2;r;g;b"
+func colorRGB(param string) (ret [3]int, ok bool) {
+	fields := colorFields(param)
+	nums := make([]int, 0, len(fields))
+	for _, p := range fields {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || n > 255 {
+			return ret, false
+		}
+		nums = append(nums, n)
+	}
+
+	switch {
+	case len(nums) == 2 && nums[0] == 5:
+		return rgb256(nums[1]), true
+	case len(nums) == 4 && nums[0] == 2:
+		return [3]int{nums[1], nums[2], nums[3]}, true
+	}
+	return ret, false
+}
+
+// rgb256 returns RGB of a color in 256 color palette
+func rgb256(n int) [3]int {
+	switch {
+	case n < 16:
+		return palette16[n]
+	case n < 232:
+		n -= 16
+		return [3]int{cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]}
+	default:
+		v := 8 + (n-232)*10
+		return [3]int{v, v, v}
+	}
+}
+
+// distance is squared euclidean distance of two colors
+func distance(a, b [3]int) int {
+	ret := 0
+	for i := range a {
+		d := a[i] - b[i]
+		ret += d * d
+	}
+	return ret
+}
+
+// nearest256 finds nearest color in the color cube or grayscale ramp of 256
+// color palette. Basic colors are skipped since terminals often change them.
+func nearest256(rgb [3]int) int {
+	ret, best := 0, -1
+	for n := 16; n < 256; n++ {
+		if d := distance(rgb, rgb256(n)); best < 0 || d < best {
+			ret, best = n, d
+		}
+	}
+	return ret
+}
+
+// nearest16 finds nearest basic color
+func nearest16(rgb [3]int) int {
+	ret, best := 0, -1
+	for n, c := range palette16 {
+		if d := distance(rgb, c); best < 0 || d < best {
+			ret, best = n, d
+		}
+	}
+	return ret
+}
+
+// String returns name of the level, like "ANSI256".
+func (l ColorLevel) String() string {
+	switch l {
+	case TrueColor:
+		return "TrueColor"
+	case ANSI256:
+		return "ANSI256"
+	case ANSI16:
+		return "ANSI16"
+	case NoColor:
+		return "NoColor"
+	}
+	return fmt.Sprintf("ColorLevel(%d)", int(l))
+}
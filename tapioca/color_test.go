@@ -0,0 +1,101 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyle_StringColors(t *testing.T) {
+	s := &style{fg: "38;2;255;135;0", bg: "48;5;236", ulColor: "58;5;196", underline: true}
+	basic := &style{fg: "91", bg: "44", bold: true}
+
+	cases := []struct {
+		level         ColorLevel
+		expect, basic string
+	}{
+		{
+			level:  TrueColor,
+			expect: "\x1b[38;2;255;135;0m\x1b[48;5;236m\x1b[4m\x1b[58;5;196m",
+			basic:  "\x1b[91m\x1b[44m\x1b[1m",
+		},
+		{
+			level:  ANSI256,
+			expect: "\x1b[38;5;208m\x1b[48;5;236m\x1b[4m\x1b[58;5;196m",
+			basic:  "\x1b[91m\x1b[44m\x1b[1m",
+		},
+		{
+			level:  ANSI16,
+			expect: "\x1b[33m\x1b[40m\x1b[4m",
+			basic:  "\x1b[91m\x1b[44m\x1b[1m",
+		},
+		{
+			level:  NoColor,
+			expect: "\x1b[4m",
+			basic:  "\x1b[1m",
+		},
+	}
+
+	defer func(old ColorLevel) { Colors = old }(Colors)
+	for _, c := range cases {
+		t.Run(c.level.String(), func(t *testing.T) {
+			Colors = c.level
+			assert.Equal(t, c.expect, s.String())
+			assert.Equal(t, c.basic, basic.String())
+		})
+	}
+}
+
+func TestDegradeColor(t *testing.T) {
+	cases := []struct {
+		param, kind string
+		level       ColorLevel
+		expect      string
+	}{
+		{"38;2;0;0;0", "38", ANSI256, "38;5;16"},
+		{"38;2;255;255;255", "38", ANSI256, "38;5;231"},
+		{"48;2;128;128;128", "48", ANSI256, "48;5;244"},
+		{"38;5;1", "38", ANSI16, "31"},
+		{"38;5;9", "38", ANSI16, "91"},
+		{"48;5;15", "48", ANSI16, "107"},
+		{"48;2;0;0;230", "48", ANSI16, "44"},
+		{"58;2;255;0;0", "58", ANSI256, "58;5;196"},
+		{"58;2;255;0;0", "58", ANSI16, ""},
+		{"38;2;300;0;0", "38", ANSI256, ""},
+		{"38;5;x", "38", ANSI16, ""},
+		{"38;5;x", "38", ANSI256, "38;5;x"},
+		{"", "38", ANSI16, ""},
+		// colon form
+		{"38:2::255:0:0", "38", TrueColor, "38:2::255:0:0"},
+		{"38:2::255:0:0", "38", ANSI256, "38;5;196"},
+		{"38:2::255:0:0", "38", ANSI16, "91"},
+		{"38:2::255:0:0", "38", NoColor, ""},
+		{"48:2:1:0:0:230", "48", ANSI256, "48;5;20"},
+		{"48:2::0:0:230", "48", ANSI16, "44"},
+		{"38:2:255:0:0", "38", ANSI16, "91"},
+		{"38:5:9", "38", ANSI256, "38:5:9"},
+		{"38:5:9", "38", ANSI16, "91"},
+		{"58:5:196", "58", ANSI256, "58:5:196"},
+		{"58:2::255:0:0", "58", ANSI256, "58;5;196"},
+		{"58:2::255:0:0", "58", ANSI16, ""},
+		{"38:2::300:0:0", "38", ANSI256, ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expect, degradeColor(c.param, c.kind, c.level), "%q at %v", c.param, c.level)
+	}
+}
+
+func TestEntry_DegradeColonColors(t *testing.T) {
+	defer func(old ColorLevel) { Colors = old }(Colors)
+	Colors = ANSI16
+
+	colon := NewEntry("\x1b[38:2::255:0:0mred").StyledString()
+	semicolon := NewEntry("\x1b[38;2;255;0;0mred").StyledString()
+	assert.Contains(t, colon, "\x1b[91m")
+	assert.Equal(t, semicolon, colon)
+}
@@ -6,32 +6,52 @@ package tapioca
 
 import (
 	"strings"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/raohwork/task/noctx/ncaction"
 	"golang.org/x/text/width"
 )
 
+// StyledRune is a grapheme cluster with its style. It occupies 1 or 2
+// columns.
 type StyledRune struct {
-	Rune  rune
+	// Rune is the base rune of the cluster
+	Rune rune
+	// Tail holds combining marks and joined runes following Rune, empty for
+	// most clusters
+	Tail  string
 	Style *style
+	// Link is target of OSC 8 hyperlink containing the rune, empty if none
+	Link string
 }
 
 // NewEntry creates a new Entry from the given string, parsing ANSI styles
 // and handling East Asian wide characters.
 func NewEntry(data string) *Entry {
-	// First, clean unsupported ANSI CSI sequences
-	data = ansiOtherRegex.ReplaceAllString(data, "")
+	// First, clean unsupported escape sequences
+	data = dropOtherANSI(data)
 
 	styledData := make([]StyledRune, 0, len(data))
 	currentStyle := &style{} // Start with a default/reset style
+	currentLink := ""
 
 	i := 0
 	for i < len(data) {
+		// Check for OSC sequence, only hyperlinks are kept
+		if data[i] == '\x1b' && i+1 < len(data) && data[i+1] == ']' {
+			if n, link, ok := parseOSC(data[i:]); n > 0 {
+				if ok {
+					currentLink = link
+				}
+				i += n
+				continue
+			}
+		}
 		// Check for ANSI escape code
 		if data[i] == '\x1b' && i+1 < len(data) && data[i+1] == '[' {
 			m := ansiStyleRegex.FindStringIndex(data[i:])
-			if m != nil {
+			if m != nil && m[0] == 0 {
 				code := data[i : i+m[1]]
 				// parseAnsiCode should be implemented in entry_ansi_style.go
 				// It must return a new style object, not modify the old one.
@@ -43,10 +63,29 @@ func NewEntry(data string) *Entry {
 
 		// Handle a regular rune
 		r, size := utf8.DecodeRuneInString(data[i:])
-		styledData = append(styledData, StyledRune{Rune: r, Style: currentStyle})
+		styledData = appendCluster(styledData, StyledRune{Rune: r, Style: currentStyle, Link: currentLink})
 		i += size
 	}
 
+	return newEntry(styledData)
+}
+
+// NewPlainEntry creates a new Entry from the given string without scanning for
+// ANSI escape sequences, which is faster than NewEntry.
+//
+// Use it only when you are sure data contains no escape sequence, otherwise
+// they will be treated as regular runes. For such input, it is identical to
+// NewEntry.
+func NewPlainEntry(data string) *Entry {
+	styledData := make([]StyledRune, 0, len(data))
+	for _, r := range data {
+		styledData = appendCluster(styledData, StyledRune{Rune: r})
+	}
+
+	return newEntry(styledData)
+}
+
+func newEntry(styledData []StyledRune) *Entry {
 	return &Entry{
 		styledData: styledData,
 		f:          ncaction.NoErrGet(computeRuneEndOffsets).By(styledData).Cached().NoErr(),
@@ -58,6 +97,11 @@ func NewEntry(data string) *Entry {
 type Entry struct {
 	styledData []StyledRune
 	f          func() []int
+
+	// memoized result of StyledBlock, valid only for blockWidth
+	blockLock  sync.Mutex
+	blockWidth int
+	block      []string
 }
 
 // runeEndOffsets returns the cumulative display widths at each rune position
@@ -77,12 +121,34 @@ func (e *Entry) Width() int {
 	return offsets[len(offsets)-1]
 }
 
+// WidthExceeds reports whether Width() > w. It stops measuring once the width
+// exceeds w, so it is much cheaper than Width() for long entries which are not
+// measured yet.
+func (e *Entry) WidthExceeds(w int) bool {
+	if w < 0 {
+		return true
+	}
+	if len(e.styledData)*2 <= w {
+		// every cluster is at most 2 columns wide
+		return false
+	}
+
+	total := 0
+	for _, r := range e.styledData {
+		total += r.width()
+		if total > w {
+			return true
+		}
+	}
+	return false
+}
+
 // String returns the plain text representation of the entry (without styles)
 func (e *Entry) String() string {
 	b := &strings.Builder{}
 	b.Grow(len(e.styledData))
 	for _, sr := range e.styledData {
-		b.WriteRune(sr.Rune)
+		sr.writeTo(b)
 	}
 	return b.String()
 }
@@ -92,28 +158,50 @@ func (e *Entry) StyledString() string {
 	return e.styledSubstring(0, len(e.styledData))
 }
 
+// StyledStringNoReset is like StyledString, but omits the trailing reset
+// sequence, leaving the terminal in the ending style of the entry.
+//
+// It is useful to concatenate styled entries efficiently. The caller is
+// responsible for resetting the style afterwards.
+func (e *Entry) StyledStringNoReset() string {
+	return e.styledSubstringWithReset(0, len(e.styledData), false)
+}
+
 // Lines returns the number of lines the entry would occupy when wrapped at the given width
 func (e *Entry) Lines(width int) int {
 	return len(e.computeWarpPoints(max(1, width)))
 }
 
+// AmbiguousWide makes East Asian ambiguous characters, like "①" and box
+// drawing characters, 2 columns wide. Default to false, which matches most
+// terminals.
+//
+// Set it before creating any Entry if your terminal renders them as wide,
+// widths of existing entries are cached and not updated.
+var AmbiguousWide = false
+
 // RuneWidth returns the display width of a rune, considering East Asian wide characters.
 func RuneWidth(r rune) int {
-	props := width.LookupRune(r)
-	if props.Kind() == width.EastAsianWide || props.Kind() == width.EastAsianFullwidth {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
 		return 2
+	case width.EastAsianAmbiguous:
+		if AmbiguousWide {
+			return 2
+		}
 	}
 	return 1
 }
 
 func computeRuneEndOffsets(styledRunes []StyledRune) []int {
-	// Pre-calculate cumulative widths for all runes
+	// Pre-calculate cumulative widths for all runes, each StyledRune is a
+	// grapheme cluster so combining marks take no extra column
 	// Example: "ab你好cd" -> []int{1,2,4,6,7,8} (cumulative widths)
 	cumulativeWidths := make([]int, len(styledRunes))
 	currentWidth := 0
 
 	for i, sr := range styledRunes {
-		runeWidth := RuneWidth(sr.Rune)
+		runeWidth := sr.width()
 
 		currentWidth += runeWidth
 		cumulativeWidths[i] = currentWidth
@@ -126,6 +214,12 @@ func computeRuneEndOffsets(styledRunes []StyledRune) []int {
 //
 // start and end are rune indices in e.styledData
 func (e *Entry) styledSubstring(start, end int) string {
+	return e.styledSubstringWithReset(start, end, true)
+}
+
+// styledSubstringWithReset is styledSubstring with control of the trailing
+// reset sequence
+func (e *Entry) styledSubstringWithReset(start, end int, reset bool) string {
 	if start >= end {
 		return ""
 	}
@@ -135,9 +229,19 @@ func (e *Entry) styledSubstring(start, end int) string {
 
 	b := &strings.Builder{}
 	var lastStyle *style
+	lastLink := ""
 
 	for i := start; i < end; i++ {
 		sr := e.styledData[i]
+		if sr.Link != lastLink {
+			if lastLink != "" {
+				b.WriteString(oscLinkEnd)
+			}
+			if sr.Link != "" {
+				b.WriteString(oscLinkStart(sr.Link))
+			}
+			lastLink = sr.Link
+		}
 		if sr.Style != lastStyle {
 			// Use the Render method to properly transition between styles
 			if lastStyle == nil {
@@ -148,11 +252,14 @@ func (e *Entry) styledSubstring(start, end int) string {
 			}
 			lastStyle = sr.Style
 		}
-		b.WriteRune(sr.Rune)
+		sr.writeTo(b)
 	}
 
+	if lastLink != "" {
+		b.WriteString(oscLinkEnd)
+	}
 	// Only append reset if we have any styling
-	if lastStyle != nil && !lastStyle.isEmpty() {
+	if reset && lastStyle != nil && !lastStyle.isEmpty() {
 		b.WriteString("\x1b[0m")
 	}
 
@@ -0,0 +1,53 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "strings"
+
+// Align controls where the padding spaces go when a line is narrower than the
+// width.
+type Align int
+
+const (
+	// AlignLeft pads spaces at right. It is the behavior of [Entry.StyledBlock].
+	AlignLeft Align = iota
+	// AlignCenter pads spaces at both sides. If the padding is odd, the extra
+	// space goes to right.
+	AlignCenter
+	// AlignRight pads spaces at left.
+	AlignRight
+)
+
+// StyledBlockAligned is like StyledBlock, but every line is aligned within
+// width as align says.
+func (e *Entry) StyledBlockAligned(width int, align Align) []string {
+	width = max(1, width)
+	if len(e.styledData) < 1 || align == AlignLeft {
+		return e.StyledBlock(width)
+	}
+
+	points := e.computeWarpPoints(width)
+	ret := make([]string, 0, len(points))
+	for _, p := range points {
+		line := e.styledSubstring(p.start, p.end)
+		padding := max(0, width-e.substringWidth(p.start, p.end))
+		left := padding
+		if align == AlignCenter {
+			left = padding / 2
+		}
+
+		buf := strings.Builder{}
+		buf.Grow(padding + len(line))
+		for range left {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(line)
+		for range padding - left {
+			buf.WriteByte(' ')
+		}
+		ret = append(ret, buf.String())
+	}
+	return ret
+}
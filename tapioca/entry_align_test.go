@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_StyledBlockAligned(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		width    int
+		align    Align
+		expected []string
+	}{
+		{name: "left", input: "abcdefg", width: 5, align: AlignLeft, expected: []string{"abcde", "fg   "}},
+		{name: "center", input: "abcdefg", width: 5, align: AlignCenter, expected: []string{"abcde", " fg  "}},
+		{name: "right", input: "abcdefg", width: 5, align: AlignRight, expected: []string{"abcde", "   fg"}},
+		{name: "center wide", input: "中文字", width: 5, align: AlignCenter, expected: []string{"中文 ", " 字  "}},
+		{name: "right wide", input: "中文字", width: 5, align: AlignRight, expected: []string{" 中文", "   字"}},
+		{
+			name:     "center styled",
+			input:    "\x1b[31mab\x1b[0m",
+			width:    6,
+			align:    AlignCenter,
+			expected: []string{"  \x1b[31mab\x1b[0m  "},
+		},
+		{name: "empty", input: "", width: 3, align: AlignRight, expected: []string{"   "}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := NewEntry(c.input).StyledBlockAligned(c.width, c.align)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "golang.org/x/text/unicode/bidi"
+
+// rune direction used in basic bidi handling
+type runeDir int
+
+const (
+	dirNeutral runeDir = iota
+	dirLTR             // strong left-to-right, and numbers
+	dirRTL             // strong right-to-left
+)
+
+func directionOf(r rune) runeDir {
+	p, _ := bidi.LookupRune(r)
+	switch p.Class() {
+	case bidi.L, bidi.EN, bidi.AN:
+		return dirLTR
+	case bidi.R, bidi.AL:
+		return dirRTL
+	}
+	return dirNeutral
+}
+
+// IsRTL reports whether the entry is a right-to-left line, which is decided by
+// the first strong directional character (like Hebrew or Arabic letters).
+func (e *Entry) IsRTL() bool {
+	for _, r := range e.styledData {
+		p, _ := bidi.LookupRune(r.Rune)
+		switch p.Class() {
+		case bidi.L:
+			return false
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return false
+}
+
+var mirroredRunes = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+}
+
+// Visual returns the entry in visual order for display, treating whole entry as
+// a right-to-left line: runes are reversed, except runs of left-to-right text
+// and numbers, and brackets are mirrored. Styles are preserved.
+//
+// It is a basic approximation of the Unicode Bidirectional Algorithm. Explicit
+// directional formatting characters and nested embedding levels are not
+// supported, and neutral characters between two left-to-right runes are
+// treated as left-to-right.
+//
+// It returns e if the entry is not RTL.
+func (e *Entry) Visual() *Entry {
+	if !e.IsRTL() {
+		return e
+	}
+
+	n := len(e.styledData)
+	dirs := make([]runeDir, n)
+	for i, r := range e.styledData {
+		dirs[i] = directionOf(r.Rune)
+	}
+	// resolve neutrals: ltr only if surrounded by ltr runes
+	ltr := make([]bool, n)
+	prev := dirRTL
+	for i := 0; i < n; i++ {
+		if dirs[i] != dirNeutral {
+			prev = dirs[i]
+			ltr[i] = dirs[i] == dirLTR
+			continue
+		}
+		next := dirRTL
+		for j := i + 1; j < n; j++ {
+			if dirs[j] != dirNeutral {
+				next = dirs[j]
+				break
+			}
+		}
+		ltr[i] = prev == dirLTR && next == dirLTR
+	}
+
+	ret := make([]StyledRune, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		if !ltr[i] {
+			r := e.styledData[i]
+			if m, ok := mirroredRunes[r.Rune]; ok {
+				r.Rune = m
+			}
+			ret = append(ret, r)
+			continue
+		}
+
+		start := i
+		for start > 0 && ltr[start-1] {
+			start--
+		}
+		ret = append(ret, e.styledData[start:i+1]...)
+		i = start
+	}
+	return newEntry(ret)
+}
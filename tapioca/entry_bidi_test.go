@@ -0,0 +1,49 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_IsRTL(t *testing.T) {
+	cases := map[string]bool{
+		"":             false,
+		"hello":        false,
+		"123 456":      false,
+		"שלום":         true,
+		"مرحبا":        true,
+		"123 שלום":     true,
+		"hello שלום":   false,
+		"\x1b[31mשלום": true,
+	}
+	for input, expect := range cases {
+		assert.Equal(t, expect, NewEntry(input).IsRTL(), "input: %q", input)
+	}
+}
+
+func TestEntry_Visual(t *testing.T) {
+	cases := []struct {
+		name, input, expect string
+	}{
+		{name: "ltr is untouched", input: "hello (world)", expect: "hello (world)"},
+		{name: "rtl only", input: "שלום עולם", expect: "םלוע םולש"},
+		{name: "numbers", input: "שלום 123", expect: "123 םולש"},
+		{name: "embedded ltr run", input: "אב go lang גד", expect: "דג go lang בא"},
+		{name: "brackets", input: "אב (גד)", expect: "(דג) בא"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expect, NewEntry(c.input).Visual().String())
+		})
+	}
+
+	t.Run("styles are preserved", func(t *testing.T) {
+		e := NewEntry("\x1b[31mאב\x1b[0m ג").Visual()
+		assert.Equal(t, "ג \x1b[31mבא\x1b[0m", e.StyledString())
+	})
+}
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "strings"
+
+// Canonical returns a stable string form of the entry, which can be parsed back
+// by [ParseCanonical] or NewEntry into an identical entry. It is meant for
+// caching and golden tests, use StyledString for terminal output.
+//
+// The format is fixed, and it will not change across versions:
+//
+//   - Text is written as is.
+//   - Whenever the style changes, "\x1b[0m" is written if previous text is
+//     styled, followed by a single SGR sequence of the new style if it is not
+//     the default style.
+//   - Parameters in the SGR sequence are in the order of foreground,
+//     background, 1, 2, 3, underline ("4" or its style like "4:3"), 5, 7, 8,
+//     9 and underline color, joined by ";". Colors are written as parsed, they
+//     are not converted by [Colors].
+//   - Hyperlinks are written as OSC 8 sequences terminated by ST. Link is
+//     closed before style is changed and opened after, if both are changed.
+//   - The entry ends with "\x1b[0m" if last text is styled, after closing the
+//     link.
+//
+// Raw escape characters in text, which is possible only with NewPlainEntry,
+// are written as is, so such entry cannot be parsed back identically.
+func (e *Entry) Canonical() string {
+	b := &strings.Builder{}
+	var cur *style
+	link := ""
+	for _, sr := range e.styledData {
+		sameStyle := canonicalSGR(sr.Style) == canonicalSGR(cur)
+		if sr.Link != link && link != "" {
+			b.WriteString(oscLinkEnd)
+		}
+		if !sameStyle {
+			if !cur.isEmpty() {
+				b.WriteString("\x1b[0m")
+			}
+			b.WriteString(canonicalSGR(sr.Style))
+			cur = sr.Style
+		}
+		if sr.Link != link && sr.Link != "" {
+			b.WriteString(oscLinkStart(sr.Link))
+		}
+		link = sr.Link
+		sr.writeTo(b)
+	}
+
+	if link != "" {
+		b.WriteString(oscLinkEnd)
+	}
+	if !cur.isEmpty() {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// ParseCanonical parses s returned by [Entry.Canonical]. It is identical to
+// NewEntry, the returned entry has same runes, styles and links as the one
+// producing s.
+func ParseCanonical(s string) *Entry {
+	return NewEntry(s)
+}
+
+// canonicalSGR renders s as a single SGR sequence in fixed order, empty if s
+// is the default style
+func canonicalSGR(s *style) string {
+	if s.isEmpty() {
+		return ""
+	}
+
+	params := make([]string, 0, 11)
+	add := func(enabled bool, param string) {
+		if enabled {
+			params = append(params, param)
+		}
+	}
+	add(s.fg != "", s.fg)
+	add(s.bg != "", s.bg)
+	add(s.bold, "1")
+	add(s.faint, "2")
+	add(s.italic, "3")
+	add(s.underline, s.underlineParam())
+	add(s.blink, "5")
+	add(s.reverse, "7")
+	add(s.hidden, "8")
+	add(s.strike, "9")
+	add(s.ulColor != "", s.ulColor)
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
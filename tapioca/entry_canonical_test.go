@@ -0,0 +1,65 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// canonicalRunes returns runes of e with styles converted to Style, as default
+// style might be nil or an empty style
+func canonicalRunes(e *Entry) []any {
+	ret := []any{}
+	for _, sr := range e.Runes() {
+		ret = append(ret, sr.Rune, sr.Tail, fromStyle(sr.Style), sr.Link)
+	}
+	return ret
+}
+
+func TestEntry_Canonical(t *testing.T) {
+	cases := []struct {
+		name, input, expect string
+	}{
+		{"plain", "hello 你好", "hello 你好"},
+		{"empty", "", ""},
+		{"reset only", "\x1b[0mabc\x1b[0m", "abc"},
+		{"style", "\x1b[31;1mred\x1b[0m", "\x1b[31;1mred\x1b[0m"},
+		{"fixed order", "\x1b[9;7;5;4;3;2;1;44;32mx", "\x1b[32;44;1;2;3;4;5;7;9mx\x1b[0m"},
+		{"transition", "\x1b[1ma\x1b[22;3mb\x1b[0mc", "\x1b[1ma\x1b[0m\x1b[3mb\x1b[0mc"},
+		{"same style", "\x1b[1ma\x1b[1mb", "\x1b[1mab\x1b[0m"},
+		{"ext colors", "\x1b[38;5;196;48;2;1;2;3;58;5;4;4:3mx", "\x1b[38;5;196;48;2;1;2;3;4:3;58;5;4mx\x1b[0m"},
+		{"colon color", "\x1b[38:2::1:2:3mx", "\x1b[38:2::1:2:3mx\x1b[0m"},
+		{"link", "a\x1b]8;;http://x\x07b\x1b]8;;\x07c", "a\x1b]8;;http://x\x1b\\b\x1b]8;;\x1b\\c"},
+		{"styled link", "\x1b]8;;u\x1b\\\x1b[1mab\x1b[0m", "\x1b[1m\x1b]8;;u\x1b\\ab\x1b]8;;\x1b\\\x1b[0m"},
+		{"cluster", "\x1b[1m🇹🇼é\x1b[0m", "\x1b[1m🇹🇼é\x1b[0m"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEntry(c.input)
+			actual := e.Canonical()
+			assert.Equal(t, c.expect, actual)
+
+			parsed := ParseCanonical(actual)
+			assert.Equal(t, canonicalRunes(e), canonicalRunes(parsed))
+			assert.Equal(t, actual, parsed.Canonical())
+		})
+	}
+}
+
+func TestEntry_CanonicalStable(t *testing.T) {
+	defer func(old ColorLevel) { Colors = old }(Colors)
+	Colors = ANSI16
+
+	e := NewEntry("\x1b[38;2;255;0;0mred\x1b[0m")
+	assert.Equal(t, "\x1b[38;2;255;0;0mred\x1b[0m", e.Canonical(), "colors are not converted")
+
+	// derived entries
+	hl := NewEntry("\x1b[32mhello\x1b[0m world").Highlight([]MatchRange{{Start: 3, End: 8}}, HighlightStyle{Reverse: true})
+	assert.Equal(t, "\x1b[32mhel\x1b[0m\x1b[32;7mlo\x1b[0m\x1b[7m wo\x1b[0mrld", hl.Canonical())
+	assert.Equal(t, canonicalRunes(hl), canonicalRunes(ParseCanonical(hl.Canonical())))
+}
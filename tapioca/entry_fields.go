@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "unicode"
+
+// Fields splits the entry around runs of whitespace, like strings.Fields. Each
+// field is a new Entry sharing the same underlying data, styles are preserved.
+func (e *Entry) Fields() []*Entry {
+	var ret []*Entry
+	start := -1 // start of current field, -1 if not in a field
+	for idx, r := range e.styledData {
+		if unicode.IsSpace(r.Rune) {
+			if start >= 0 {
+				ret = append(ret, e.sub(start, idx))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = idx
+		}
+	}
+	if start >= 0 {
+		ret = append(ret, e.sub(start, len(e.styledData)))
+	}
+	return ret
+}
@@ -0,0 +1,43 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Fields(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect []string
+	}{
+		{name: "empty", input: "", expect: nil},
+		{name: "spaces only", input: " \t ", expect: nil},
+		{name: "single", input: "hello", expect: []string{"hello"}},
+		{name: "runs of whitespace", input: "  a \t bb\tccc  ", expect: []string{"a", "bb", "ccc"}},
+		{name: "wide characters", input: "中文 字", expect: []string{"中文", "字"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var actual []string
+			for _, f := range NewEntry(c.input).Fields() {
+				actual = append(actual, f.String())
+			}
+			assert.Equal(t, c.expect, actual)
+		})
+	}
+
+	t.Run("styles are preserved", func(t *testing.T) {
+		fields := NewEntry("\x1b[31mred  text\x1b[0m plain").Fields()
+		assert.Len(t, fields, 3)
+		assert.Equal(t, "\x1b[31mred\x1b[0m", fields[0].StyledString())
+		assert.Equal(t, "\x1b[31mtext\x1b[0m", fields[1].StyledString())
+		assert.Equal(t, "plain", fields[2].StyledString())
+	})
+}
@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MatchRange is a range of display columns, End is exclusive.
+type MatchRange struct {
+	Start, End int
+}
+
+// Find returns starting display columns of non-overlapping matches of substr
+// in String(), ANSI styles are ignored. Empty substr matches nothing.
+func (e *Entry) Find(substr string) []int {
+	if substr == "" {
+		return nil
+	}
+
+	str := e.String()
+	columns := e.columnMapper()
+	var ret []int
+	for pos := 0; ; {
+		idx := strings.Index(str[pos:], substr)
+		if idx < 0 {
+			break
+		}
+		start, _ := columns(pos+idx, pos+idx+len(substr))
+		ret = append(ret, start)
+		pos += idx + len(substr)
+	}
+	return ret
+}
+
+// FindRegexp returns display columns of non-overlapping matches of re in
+// String(), ANSI styles are ignored.
+//
+// A match starting or ending in the middle of a grapheme cluster is extended
+// to the whole cluster.
+func (e *Entry) FindRegexp(re *regexp.Regexp) []MatchRange {
+	matches := re.FindAllStringIndex(e.String(), -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	columns := e.columnMapper()
+	ret := make([]MatchRange, len(matches))
+	for i, m := range matches {
+		ret[i].Start, ret[i].End = columns(m[0], m[1])
+	}
+	return ret
+}
+
+// columnMapper returns a function mapping byte offsets [start, end) in String()
+// to display columns
+func (e *Entry) columnMapper() func(start, end int) (int, int) {
+	// byte offset where each cluster begins
+	begins := make([]int, len(e.styledData))
+	n := 0
+	for i, sr := range e.styledData {
+		begins[i] = n
+		n += utf8.RuneLen(sr.Rune) + len(sr.Tail)
+	}
+
+	offsets := e.runeEndOffsets()
+	// column where the cluster containing byte offset b begins
+	startCol := func(b int) int {
+		idx := sort.Search(len(begins), func(i int) bool { return begins[i] > b }) - 1
+		if idx <= 0 {
+			return 0
+		}
+		return offsets[idx-1]
+	}
+	// column where the cluster containing byte offset b ends
+	endCol := func(b int) int {
+		idx := sort.Search(len(begins), func(i int) bool { return begins[i] > b }) - 1
+		if idx < 0 {
+			return 0
+		}
+		return offsets[idx]
+	}
+
+	return func(start, end int) (int, int) {
+		if start >= n {
+			return e.Width(), e.Width()
+		}
+		if end <= start {
+			return startCol(start), startCol(start)
+		}
+		return startCol(start), endCol(end - 1)
+	}
+}
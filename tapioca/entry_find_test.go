@@ -0,0 +1,47 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Find(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		substr   string
+		expected []int
+	}{
+		{name: "ascii", input: "abcabc", substr: "bc", expected: []int{1, 4}},
+		{name: "non-overlapping", input: "aaaa", substr: "aa", expected: []int{0, 2}},
+		{name: "wide", input: "你好ab好", substr: "好", expected: []int{2, 6}},
+		{name: "styles", input: "\x1b[31merr\x1b[0m: err", substr: "err", expected: []int{0, 5}},
+		{name: "combining", input: "e\u0301x", substr: "x", expected: []int{1}},
+		{name: "not found", input: "abc", substr: "x"},
+		{name: "empty", input: "abc", substr: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, NewEntry(c.input).Find(c.substr))
+		})
+	}
+}
+
+func TestEntry_FindRegexp(t *testing.T) {
+	e := NewEntry("id=\x1b[1m你42\x1b[0m x=7")
+	assert.Equal(t, []MatchRange{{Start: 5, End: 7}, {Start: 10, End: 11}}, e.FindRegexp(regexp.MustCompile(`\d+`)))
+	assert.Equal(t, []MatchRange{{Start: 3, End: 7}}, e.FindRegexp(regexp.MustCompile(`你\d+`)))
+	assert.Nil(t, e.FindRegexp(regexp.MustCompile(`y`)))
+
+	// match inside a cluster covers the whole cluster
+	e = NewEntry("ae\u0301b")
+	assert.Equal(t, []MatchRange{{Start: 1, End: 2}}, e.FindRegexp(regexp.MustCompile("\u0301")))
+	assert.Equal(t, []MatchRange{{Start: 3, End: 3}}, e.FindRegexp(regexp.MustCompile(`$`)))
+}
@@ -5,6 +5,7 @@
 package tapioca
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,3 +20,62 @@ func TestEntry_Width(t *testing.T) {
 		})
 	}
 }
+
+func TestEntry_WidthExceeds(t *testing.T) {
+	for _, tc := range onelineTestCase {
+		t.Run(tc.name, func(t *testing.T) {
+			e := NewEntry(tc.input)
+			for _, w := range []int{-1, 0, tc.width - 1, tc.width, tc.width + 1, tc.width * 2} {
+				assert.Equal(t, tc.width > w, e.WidthExceeds(w), "w = %d", w)
+			}
+		})
+	}
+}
+
+func TestEntry_AmbiguousWide(t *testing.T) {
+	defer func() { AmbiguousWide = false }()
+
+	assert.Equal(t, 4, NewEntry("①─ab").Width())
+	AmbiguousWide = true
+	assert.Equal(t, 6, NewEntry("①─ab").Width())
+	assert.Equal(t, []string{"①─", "ab  "}, NewEntry("①─ab").StyledBlock(4))
+	assert.Equal(t, 2, RuneWidth('你'))
+	assert.Equal(t, 1, RuneWidth('a'))
+}
+
+func BenchmarkEntry_WidthExceeds(b *testing.B) {
+	e := NewPlainEntry(strings.Repeat("log line 日誌 ", 1000))
+
+	b.Run("Width", func(b *testing.B) {
+		for b.Loop() {
+			// what Width() does for an entry not measured yet
+			offsets := computeRuneEndOffsets(e.styledData)
+			_ = offsets[len(offsets)-1] > 80
+		}
+	})
+	b.Run("WidthExceeds", func(b *testing.B) {
+		for b.Loop() {
+			_ = e.WidthExceeds(80)
+		}
+	})
+}
+
+func TestNewPlainEntry(t *testing.T) {
+	cases := []string{
+		"",
+		"simple",
+		"ab你好cd",
+		"全形ＡＢＣ",
+		"tab\tand space",
+	}
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			expect := NewEntry(c)
+			got := NewPlainEntry(c)
+			assert.Equal(t, expect.Width(), got.Width())
+			assert.Equal(t, expect.String(), got.String())
+			assert.Equal(t, expect.StyledString(), got.StyledString())
+			assert.Equal(t, expect.StyledBlock(3), got.StyledBlock(3))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"strings"
+	"unicode"
+)
+
+const zwj = '\u200d'
+
+// joinsCluster reports whether r continues the grapheme cluster of prev
+// instead of starting a new one.
+//
+// It covers common cases in terminals: combining marks, variation selectors,
+// emoji modifiers and tags, ZWJ sequences and regional indicator pairs (flags).
+func joinsCluster(prev StyledRune, r rune) bool {
+	if strings.HasSuffix(prev.Tail, string(zwj)) {
+		return true
+	}
+
+	switch {
+	case r == zwj:
+		return true
+	case r >= 0xfe00 && r <= 0xfe0f, r >= 0xe0100 && r <= 0xe01ef:
+		// variation selectors
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff:
+		// emoji skin tone modifiers
+		return true
+	case r >= 0xe0020 && r <= 0xe007f:
+		// tags, used by subdivision flags
+		return true
+	case isRegionalIndicator(r):
+		return isRegionalIndicator(prev.Rune) && prev.Tail == ""
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// appendCluster appends sr to data, or merges it into last cluster if it is a
+// combining rune.
+func appendCluster(data []StyledRune, sr StyledRune) []StyledRune {
+	if n := len(data); n > 0 && joinsCluster(data[n-1], sr.Rune) {
+		data[n-1].Tail += string(sr.Rune)
+		return data
+	}
+	return append(data, sr)
+}
+
+// width returns display width of the cluster.
+func (sr StyledRune) width() int {
+	if isRegionalIndicator(sr.Rune) {
+		// flags, and unpaired indicator which is shown as a letter in a box
+		return 2
+	}
+	if sr.Tail == "" {
+		return RuneWidth(sr.Rune)
+	}
+	if strings.ContainsRune(sr.Tail, '\ufe0f') {
+		// emoji presentation
+		return 2
+	}
+	return RuneWidth(sr.Rune)
+}
+
+// writeTo writes runes of the cluster to b.
+func (sr StyledRune) writeTo(b *strings.Builder) {
+	b.WriteRune(sr.Rune)
+	b.WriteString(sr.Tail)
+}
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Grapheme(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		width   int
+		offsets []int
+	}{
+		{name: "combining accent", input: "cafe\u0301!", width: 5, offsets: []int{1, 2, 3, 4, 5}},
+		{name: "multiple marks", input: "a\u0300\u0301b", width: 2, offsets: []int{1, 2}},
+		{name: "zwj family", input: "a\U0001F468\u200d\U0001F469\u200d\U0001F467b", width: 4, offsets: []int{1, 3, 4}},
+		{name: "skin tone", input: "\U0001F44D\U0001F3FD", width: 2, offsets: []int{2}},
+		{name: "emoji presentation", input: "\u2764\ufe0f", width: 2, offsets: []int{2}},
+		{name: "flags", input: "\U0001F1F9\U0001F1FC\U0001F1EF\U0001F1F5", width: 4, offsets: []int{2, 4}},
+		{name: "unpaired indicator", input: "\U0001F1F9\U0001F1FC\U0001F1EFa", width: 5, offsets: []int{2, 4, 5}},
+		{name: "zwj with presentation", input: "\U0001F3F3\ufe0f\u200d\U0001F308", width: 2, offsets: []int{2}},
+		{name: "leading mark", input: "\u0301a", width: 2, offsets: []int{1, 2}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, e := range []*Entry{NewEntry(c.input), NewPlainEntry(c.input)} {
+				assert.Equal(t, c.width, e.Width())
+				assert.Equal(t, c.offsets, e.runeEndOffsets())
+				assert.Equal(t, c.input, e.String())
+				assert.False(t, e.WidthExceeds(c.width))
+			}
+		})
+	}
+}
+
+func TestEntry_GraphemeStyled(t *testing.T) {
+	e := NewEntry("\x1b[31me\u0301\x1b[32mx\x1b[0m")
+	assert.Equal(t, 2, e.Width())
+	assert.Equal(t, "\x1b[31me\u0301\x1b[0m", e.StyledMove(0, 1))
+	assert.Equal(t, []string{"e\u0301", "x"}, warpPlain(e, 1))
+}
+
+func TestEntry_GraphemeRedraw(t *testing.T) {
+	e := NewEntry("abc").Redraw("\re\u0301")
+	assert.Equal(t, "e\u0301bc", e.String())
+	assert.Equal(t, 3, e.Width())
+
+	e = NewEntry("ab").Redraw("\u2764\ufe0f")
+	assert.Equal(t, "ab\u2764\ufe0f", e.String())
+	e = e.Redraw("\rxyz!")
+	assert.Equal(t, "xyz!", e.String())
+}
+
+const (
+	flagTW = "\U0001F1F9\U0001F1FC"
+	family = "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+)
+
+func TestEntry_GraphemeShift(t *testing.T) {
+	// columns: a=0, family=1-2, b=3, flag=4-5, c=6
+	e := NewEntry("\x1b[31ma" + family + "b" + flagTW + "c")
+	cases := []struct {
+		start, width int
+		expect       string
+		cutL, cutR   bool
+	}{
+		{start: 0, width: 3, expect: "a" + family},
+		{start: 0, width: 2, expect: "a ", cutR: true},
+		{start: 2, width: 2, expect: " b", cutL: true},
+		{start: 1, width: 2, expect: family},
+		{start: 3, width: 3, expect: "b" + flagTW},
+		{start: 3, width: 2, expect: "b ", cutR: true},
+		{start: 5, width: 2, expect: " c", cutL: true},
+		{start: 4, width: 3, expect: flagTW + "c"},
+	}
+	for _, c := range cases {
+		s, l, r := e.StyledShiftEx(c.start, c.width)
+		assert.Equal(t, c.expect, NewEntry(s).String(), "(%d, %d)", c.start, c.width)
+		assert.Equal(t, c.cutL, l, "(%d, %d) cut left", c.start, c.width)
+		assert.Equal(t, c.cutR, r, "(%d, %d) cut right", c.start, c.width)
+	}
+
+	// adjacent flags never pair across each other
+	e = NewEntry(flagTW + flagTW + flagTW)
+	assert.Equal(t, "  ", e.StyledShift(1, 2))
+	assert.Equal(t, " "+flagTW+" ", e.StyledMove(1, 4))
+	assert.Equal(t, " "+flagTW, e.StyledWindow(3, 3))
+}
+
+func TestEntry_GraphemeWarp(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		width  int
+		expect []string
+	}{
+		{
+			name:   "family at edge",
+			input:  "ab" + family + "c",
+			width:  3,
+			expect: []string{"ab ", family + "c"},
+		},
+		{
+			name:   "flags at edge",
+			input:  "a" + flagTW + flagTW,
+			width:  4,
+			expect: []string{"a" + flagTW + " ", flagTW + "  "},
+		},
+		{
+			name:   "narrower than cluster",
+			input:  family + flagTW,
+			width:  1,
+			expect: []string{family, flagTW},
+		},
+		{
+			name:   "exact fit",
+			input:  flagTW + family,
+			width:  2,
+			expect: []string{flagTW, family},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEntry(c.input)
+			assert.Equal(t, c.expect, warpPlain(e, c.width))
+			assert.Len(t, e.StyledWarps(c.width), len(c.expect))
+		})
+	}
+}
+
+func warpPlain(e *Entry, width int) []string {
+	var ret []string
+	for _, l := range e.StyledBlock(width) {
+		ret = append(ret, NewEntry(l).String())
+	}
+	return ret
+}
@@ -0,0 +1,70 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "sort"
+
+// HighlightStyle is merged over existing style of highlighted text by
+// [Entry.Highlight]. Empty or false fields keep existing style.
+type HighlightStyle struct {
+	// Fg and Bg are SGR parameters of colors, like "33", "93", "38;5;208" or
+	// "48;2;40;40;40".
+	Fg, Bg    string
+	Bold      bool
+	Faint     bool
+	Italic    bool
+	Underline bool
+	Reverse   bool
+}
+
+// merge returns a new style with h applied over s
+func (h HighlightStyle) merge(s *style) *style {
+	return s.Merge(&style{
+		fg:        h.Fg,
+		bg:        h.Bg,
+		bold:      h.Bold,
+		faint:     h.Faint,
+		italic:    h.Italic,
+		underline: h.Underline,
+		reverse:   h.Reverse,
+	})
+}
+
+// Highlight returns a new entry with text in ranges restyled by merging hl
+// over existing style. Ranges are display columns, like what
+// [Entry.FindRegexp] returns. A wide rune is highlighted if any of its columns
+// is in a range.
+//
+// The receiver is not modified.
+func (e *Entry) Highlight(ranges []MatchRange, hl HighlightStyle) *Entry {
+	data := make([]StyledRune, len(e.styledData))
+	copy(data, e.styledData)
+	offsets := e.runeEndOffsets()
+
+	// runes with same style share the merged style, so it is not rendered
+	// again between them
+	merged := map[*style]*style{}
+	for _, r := range ranges {
+		// first rune ending after r.Start
+		i := sort.Search(len(offsets), func(i int) bool { return offsets[i] > r.Start })
+		for ; i < len(data) && offsets[i]-data[i].width() < r.End; i++ {
+			s, ok := merged[e.styledData[i].Style]
+			if !ok {
+				s = hl.merge(e.styledData[i].Style)
+				merged[e.styledData[i].Style] = s
+			}
+			data[i].Style = s
+		}
+	}
+	return newEntry(data)
+}
+
+// WithReverse returns a new entry with reverse video (SGR 7) applied over
+// existing style of all runes. It is useful to highlight selected row as it
+// works in terminals with unreliable background colors. Pad the entry to full
+// width before calling it if whole row should be highlighted.
+func (e *Entry) WithReverse() *Entry {
+	return e.Highlight([]MatchRange{{Start: 0, End: e.Width()}}, HighlightStyle{Reverse: true})
+}
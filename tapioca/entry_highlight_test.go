@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Highlight(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		ranges   []MatchRange
+		hl       HighlightStyle
+		expected string
+	}{
+		{
+			name:     "plain",
+			input:    "abcdef",
+			ranges:   []MatchRange{{Start: 1, End: 3}},
+			hl:       HighlightStyle{Bg: "43"},
+			expected: "a\x1b[43mbc\x1b[0mdef",
+		},
+		{
+			name:     "split style run",
+			input:    "\x1b[31mabcd\x1b[0mef",
+			ranges:   []MatchRange{{Start: 2, End: 5}},
+			hl:       HighlightStyle{Bg: "43", Bold: true},
+			expected: "\x1b[31mab\x1b[43m\x1b[1mcd\x1b[39me\x1b[0mf",
+		},
+		{
+			name:     "override color",
+			input:    "\x1b[31mab\x1b[0m",
+			ranges:   []MatchRange{{Start: 0, End: 1}},
+			hl:       HighlightStyle{Fg: "32"},
+			expected: "\x1b[32ma\x1b[31mb\x1b[0m",
+		},
+		{
+			name:     "wide rune partially covered",
+			input:    "a你b",
+			ranges:   []MatchRange{{Start: 2, End: 3}},
+			hl:       HighlightStyle{Reverse: true},
+			expected: "a\x1b[7m你\x1b[0mb",
+		},
+		{
+			name:     "multiple ranges",
+			input:    "abcabc",
+			ranges:   []MatchRange{{Start: 0, End: 1}, {Start: 3, End: 4}, {Start: 10, End: 12}},
+			hl:       HighlightStyle{Underline: true},
+			expected: "\x1b[4ma\x1b[0mbc\x1b[4ma\x1b[0mbc",
+		},
+		{
+			name:     "empty range",
+			input:    "abc",
+			ranges:   []MatchRange{{Start: 1, End: 1}},
+			hl:       HighlightStyle{Bold: true},
+			expected: "abc",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEntry(c.input)
+			before := e.StyledString()
+			got := e.Highlight(c.ranges, c.hl)
+			assert.Equal(t, c.expected, got.StyledString())
+			assert.Equal(t, e.String(), got.String())
+			assert.Equal(t, before, e.StyledString(), "receiver is not modified")
+		})
+	}
+}
+
+func TestEntry_HighlightFind(t *testing.T) {
+	e := NewEntry("err: 你 error")
+	got := e.Highlight(e.FindRegexp(regexp.MustCompile(`err`)), HighlightStyle{Fg: "31"})
+	assert.Equal(t, "\x1b[31merr\x1b[0m: 你 \x1b[31merr\x1b[0mor", got.StyledString())
+}
+
+func TestEntry_WithReverse(t *testing.T) {
+	e := NewEntry("a\x1b[31mb\x1b[7mc\x1b[0m")
+	before := e.StyledString()
+	got := e.WithReverse()
+	assert.Equal(t, "\x1b[7ma\x1b[31mbc\x1b[0m", got.StyledString())
+	assert.Equal(t, before, e.StyledString(), "receiver is not modified")
+
+	assert.Equal(t, "", NewEntry("").WithReverse().StyledString())
+	row := NewEntry(NewEntry("ab").StyledMove(0, 4))
+	assert.Equal(t, "\x1b[7mab  \x1b[0m", row.WithReverse().StyledString())
+}
@@ -0,0 +1,50 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Hyperlink(t *testing.T) {
+	open := "\x1b]8;;https://example.com\x1b\\"
+	closeLink := "\x1b]8;;\x1b\\"
+	e := NewEntry("see " + open + "example" + closeLink + " now")
+
+	assert.Equal(t, 15, e.Width())
+	assert.Equal(t, "see example now", e.String())
+	assert.Equal(t, "see "+open+"example"+closeLink+" now", e.StyledString())
+
+	t.Run("StyledMove", func(t *testing.T) {
+		assert.Equal(t, open+"amp"+closeLink, e.StyledMove(6, 3))
+		assert.Equal(t, " "+open+"ex"+closeLink, e.StyledMove(3, 3))
+	})
+
+	t.Run("StyledBlock", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"see " + open + "ex" + closeLink,
+			open + "ample" + closeLink + " ",
+			"now   ",
+		}, e.StyledBlock(6))
+	})
+
+	t.Run("with styles and BEL", func(t *testing.T) {
+		e := NewEntry("\x1b[31m\x1b]8;id=1;http://a\x07ab\x1b]8;;\x07\x1b[0mc")
+		assert.Equal(t, 3, e.Width())
+		assert.Equal(t, oscLinkStart("http://a")+"\x1b[31mab"+oscLinkEnd+"\x1b[0mc", e.StyledString())
+	})
+
+	t.Run("other OSC is dropped", func(t *testing.T) {
+		e := NewEntry("\x1b]0;title\x07abc")
+		assert.Equal(t, "abc", e.StyledString())
+	})
+
+	t.Run("Redraw", func(t *testing.T) {
+		e := NewEntry("").Redraw("xxxx\r" + open + "ab" + closeLink)
+		assert.Equal(t, open+"ab"+closeLink+"xx", e.StyledString())
+	})
+}
@@ -91,3 +91,55 @@ func TestEntry_StyledMove(t *testing.T) {
 		})
 	}
 }
+
+// viewport covering no character is filled with blanks, without any styled
+// character leaking in
+func TestEntry_StyledMove_OutOfRange(t *testing.T) {
+	cases := []struct {
+		name         string
+		input        string
+		start, width int
+		expected     string
+	}{
+		{name: "beyond the end", input: "\x1b[31mHi\x1b[0m", start: 3, width: 4, expected: "    "},
+		{name: "right at the end", input: "\x1b[31mHi\x1b[0m", start: 2, width: 3, expected: "   "},
+		{name: "before the beginning", input: "\x1b[31mHi\x1b[0m", start: -4, width: 4, expected: "    "},
+		{name: "wide text", input: "\x1b[32mこん\x1b[0m", start: 5, width: 2, expected: "  "},
+		{name: "zero width", input: "Hi", start: 0, width: 0, expected: ""},
+		{name: "negative width", input: "Hi", start: 4, width: -1, expected: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewEntry(tc.input).StyledMove(tc.start, tc.width)
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, max(0, tc.width), NewEntry(got).Width())
+		})
+	}
+}
+
+func TestEntry_StyledMovePad(t *testing.T) {
+	cases := []struct {
+		name         string
+		input        string
+		start, width int
+		pad          rune
+		expected     string
+	}{
+		{name: "prefix and suffix", input: "\x1b[31mHi\x1b[0m", start: -2, width: 6, pad: '·', expected: "··\x1b[31mHi\x1b[0m··"},
+		{name: "out of range", input: "Hi", start: 5, width: 3, pad: '░', expected: "░░░"},
+		{name: "no padding", input: "Hello", start: 1, width: 3, pad: '·', expected: "ell"},
+		{name: "wide pad, even", input: "Hi", start: -4, width: 6, pad: '＊', expected: "＊＊Hi"},
+		{name: "wide pad, odd", input: "Hi", start: -3, width: 6, pad: '＊', expected: "＊ Hi "},
+		{name: "wide pad, empty entry", input: "", start: 0, width: 5, pad: '＊', expected: "＊＊ "},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := NewEntry(tc.input)
+			got := e.StyledMovePad(tc.start, tc.width, tc.pad)
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.width, NewEntry(got).Width())
+		})
+	}
+}
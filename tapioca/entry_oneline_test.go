@@ -5,6 +5,7 @@
 package tapioca
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,13 +45,13 @@ var onelineTestCase = []struct {
 	{
 		name:     "nested style (bold + red), need reset at end",
 		input:    "\x1b[1mbold \x1b[31mred",
-		expected: "\x1b[1mbold \x1b[0m\x1b[31m\x1b[1mred\x1b[0m",
+		expected: "\x1b[1mbold \x1b[31mred\x1b[0m",
 		width:    8,
 	},
 	{
 		name:     "nested style with reset (bold + red), no reset at end",
 		input:    "\x1b[1mbold\x1b[31mred\x1b[0mnor",
-		expected: "\x1b[1mbold\x1b[0m\x1b[31m\x1b[1mred\x1b[0mnor",
+		expected: "\x1b[1mbold\x1b[31mred\x1b[0mnor",
 		width:    10,
 	},
 	{
@@ -67,9 +68,9 @@ var onelineTestCase = []struct {
 		expected: "n" +
 			"\x1b[1mN" +
 			"\x1b[0m\x1b[34mb" +
-			"\x1b[0m\x1b[34m\x1b[1mB" +
+			"\x1b[1mB" +
 			"\x1b[0m\x1b[31mr" +
-			"\x1b[0m\x1b[31m\x1b[1mR" +
+			"\x1b[1mR" +
 			"\x1b[0mn",
 		width: 7,
 	},
@@ -84,3 +85,20 @@ func TestEntry_StyledString(t *testing.T) {
 		})
 	}
 }
+
+func TestEntry_StyledStringNoReset(t *testing.T) {
+	for _, tc := range onelineTestCase {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := NewEntry(tc.input)
+			got := entry.StyledStringNoReset()
+			assert.Equal(t, strings.TrimSuffix(tc.expected, "\x1b[0m"), got)
+		})
+	}
+
+	t.Run("concatenation", func(t *testing.T) {
+		a := NewEntry("\x1b[31mred")
+		b := NewEntry("plain")
+		got := NewEntry(a.StyledStringNoReset() + "\x1b[0m" + b.StyledString())
+		assert.Equal(t, "\x1b[31mred\x1b[0mplain", got.StyledString())
+	})
+}
@@ -0,0 +1,163 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+var (
+	eraseLineRegex = regexp.MustCompile(`^\x1b\[([012]?)K`)
+	redrawRegex    = regexp.MustCompile(`\r|\x1b\[[012]?K`)
+)
+
+// HasRedraw reports whether data contains carriage return or erase line
+// sequence, which should be handled by [Entry.Redraw] instead of NewEntry.
+func HasRedraw(data string) bool {
+	return redrawRegex.MatchString(data)
+}
+
+// Redraw returns a new entry as if a line of data is written to a terminal
+// right after e, with cursor at the end of e. It is used to display output of
+// programs which redraw a line to show progress, like curl or apt.
+//
+// Besides styles, it handles:
+//
+//   - "\r": moves cursor to the beginning of the line, following text
+//     overwrites existing content.
+//   - "\x1b[K" or "\x1b[0K": erases from cursor to end of the line.
+//   - "\x1b[1K": erases from beginning of the line to cursor.
+//   - "\x1b[2K": erases whole line.
+//
+// So "\r" + content + "\x1b[K" replaces the line with content. Erase sequence
+// without a preceding "\r" takes effect at current cursor position, which is
+// the end of text written so far. Erased columns before cursor become spaces.
+// Other escape sequences are dropped like NewEntry.
+func (e *Entry) Redraw(data string) *Entry {
+	// every cell is a column, second column of a wide rune is a placeholder
+	// with rune 0
+	cells := make([]StyledRune, 0, len(e.styledData)+len(data))
+	for _, sr := range e.styledData {
+		cells = append(cells, sr)
+		if sr.width() == 2 {
+			cells = append(cells, StyledRune{})
+		}
+	}
+	cursor := len(cells)
+
+	// blank wide rune partially covered by column i
+	splitWide := func(i int) {
+		if i >= len(cells) {
+			return
+		}
+		if cells[i].Rune == 0 && i > 0 {
+			cells[i-1] = StyledRune{Rune: ' '}
+		}
+		if i+1 < len(cells) && cells[i+1].Rune == 0 && cells[i].width() == 2 {
+			cells[i+1] = StyledRune{Rune: ' '}
+		}
+	}
+	put := func(sr StyledRune) {
+		// combining rune joins the cluster right before cursor
+		if prev := cursor - 1; prev >= 0 && prev < len(cells) {
+			if cells[prev].Rune == 0 && prev > 0 {
+				prev--
+			}
+			if cells[prev].Rune != 0 && joinsCluster(cells[prev], sr.Rune) {
+				w := cells[prev].width()
+				cells[prev].Tail += string(sr.Rune)
+				if w == 1 && cells[prev].width() == 2 {
+					sr = StyledRune{}
+				} else {
+					return
+				}
+			}
+		}
+
+		splitWide(cursor)
+		if cursor == len(cells) {
+			cells = append(cells, sr)
+		} else {
+			cells[cursor] = sr
+		}
+		cursor++
+		if sr.Rune != 0 && sr.width() == 2 {
+			splitWide(cursor)
+			if cursor == len(cells) {
+				cells = append(cells, StyledRune{})
+			} else {
+				cells[cursor] = StyledRune{}
+			}
+			cursor++
+		}
+	}
+
+	var currentStyle *style
+	if n := len(e.styledData); n > 0 {
+		currentStyle = e.styledData[n-1].Style
+	}
+	currentLink := ""
+	i := 0
+	for i < len(data) {
+		if data[i] == '\x1b' && i+1 < len(data) && data[i+1] == ']' {
+			if n, link, ok := parseOSC(data[i:]); n > 0 {
+				if ok {
+					currentLink = link
+				}
+				i += n
+				continue
+			}
+		}
+		if data[i] == '\r' {
+			cursor = 0
+			i++
+			continue
+		}
+		if data[i] == '\x1b' && i+1 < len(data) && data[i+1] == '[' {
+			if m := eraseLineRegex.FindStringSubmatch(data[i:]); m != nil {
+				splitWide(cursor)
+				switch m[1] {
+				case "1":
+					for j := 0; j <= cursor && j < len(cells); j++ {
+						cells[j] = StyledRune{Rune: ' '}
+					}
+				case "2":
+					cells = cells[:cursor]
+					for j := range cells {
+						cells[j] = StyledRune{Rune: ' '}
+					}
+				default:
+					cells = cells[:cursor]
+				}
+				i += len(m[0])
+				continue
+			}
+			if m := ansiStyleRegex.FindStringIndex(data[i:]); m != nil && m[0] == 0 {
+				currentStyle = parseAnsiCode(data[i:i+m[1]], currentStyle)
+				i += m[1]
+				continue
+			}
+		}
+		if data[i] == '\x1b' {
+			if m := ansiOtherRegex.FindStringIndex(data[i:]); m != nil && m[0] == 0 {
+				i += m[1]
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(data[i:])
+		put(StyledRune{Rune: r, Style: currentStyle, Link: currentLink})
+		i += size
+	}
+
+	styledData := make([]StyledRune, 0, len(cells))
+	for _, c := range cells {
+		if c.Rune != 0 {
+			styledData = append(styledData, c)
+		}
+	}
+	return newEntry(styledData)
+}
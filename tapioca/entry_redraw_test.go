@@ -0,0 +1,49 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Redraw(t *testing.T) {
+	cases := []struct {
+		name     string
+		prev     string
+		data     string
+		expected string
+	}{
+		{name: "plain", prev: "", data: "abc", expected: "abc"},
+		{name: "append", prev: "ab", data: "cd", expected: "abcd"},
+		{name: "carriage return overwrites", prev: "", data: "12345\rab", expected: "ab345"},
+		{name: "replace line", prev: "", data: "12345\rab\x1b[K", expected: "ab"},
+		{name: "replace previous", prev: "downloading 10%", data: "\rdone\x1b[K", expected: "done"},
+		{name: "erase without cr", prev: "abc", data: "\x1b[K", expected: "abc"},
+		{name: "erase with 0", prev: "", data: "abc\r\x1b[0K", expected: ""},
+		{name: "erase to cursor", prev: "", data: "abcde\rab\x1b[1K", expected: "   de"},
+		{name: "erase whole line", prev: "", data: "abc\x1b[2Kd", expected: "   d"},
+		{name: "wide char overwritten", prev: "中文", data: "\ra", expected: "a 文"},
+		{name: "wide char over narrow", prev: "abc", data: "\r中", expected: "中c"},
+		{name: "wide char partially erased", prev: "", data: "中文\ra\x1b[K", expected: "a"},
+		{name: "styles", prev: "", data: "\x1b[31m10%\r20%", expected: "\x1b[31m20%\x1b[0m"},
+		{name: "styles continue", prev: "\x1b[31mab", data: "c", expected: "\x1b[31mabc\x1b[0m"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEntry(c.prev).Redraw(c.data)
+			assert.Equal(t, c.expected, e.StyledString())
+		})
+	}
+}
+
+func TestHasRedraw(t *testing.T) {
+	assert.True(t, HasRedraw("\rabc"))
+	assert.True(t, HasRedraw("abc\x1b[K"))
+	assert.True(t, HasRedraw("abc\x1b[2K"))
+	assert.False(t, HasRedraw("OK \x1b[31mKK\x1b[0m"))
+}
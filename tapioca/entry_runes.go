@@ -0,0 +1,36 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "sort"
+
+// Runes returns a copy of decoded runes with their styles, so you can analyze
+// or restyle the content without losing style info.
+func (e *Entry) Runes() []StyledRune {
+	ret := make([]StyledRune, len(e.styledData))
+	copy(ret, e.styledData)
+	return ret
+}
+
+// RuneAt returns the rune covering display column col, which starts from 0.
+// Both columns of a wide rune map to it. It returns false if col is out of
+// range.
+func (e *Entry) RuneAt(col int) (StyledRune, bool) {
+	if col < 0 {
+		return StyledRune{}, false
+	}
+	offsets := e.runeEndOffsets()
+	idx := sort.Search(len(offsets), func(i int) bool { return offsets[i] > col })
+	if idx >= len(offsets) {
+		return StyledRune{}, false
+	}
+	return e.styledData[idx], true
+}
+
+// SGR returns the ANSI SGR sequence of the style, empty if the rune is not
+// styled.
+func (sr StyledRune) SGR() string {
+	return sr.Style.String()
+}
@@ -0,0 +1,52 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Runes(t *testing.T) {
+	e := NewEntry("a\x1b[1;31m你\x1b[0mb")
+	runes := e.Runes()
+	assert.Len(t, runes, 3)
+	assert.Equal(t, 'a', runes[0].Rune)
+	assert.Equal(t, "", runes[0].SGR())
+	assert.Equal(t, '你', runes[1].Rune)
+	assert.Equal(t, "\x1b[31m\x1b[1m", runes[1].SGR())
+
+	// modifying the copy does not affect the entry
+	runes[0].Rune = 'x'
+	assert.Equal(t, "a你b", e.String())
+}
+
+func TestEntry_RuneAt(t *testing.T) {
+	e := NewEntry("a\x1b[31m你\x1b[0mb")
+	cases := []struct {
+		col  int
+		r    rune
+		sgr  string
+		want bool
+	}{
+		{col: -1},
+		{col: 0, r: 'a', want: true},
+		{col: 1, r: '你', sgr: "\x1b[31m", want: true},
+		{col: 2, r: '你', sgr: "\x1b[31m", want: true},
+		{col: 3, r: 'b', want: true},
+		{col: 4},
+	}
+
+	for _, c := range cases {
+		sr, ok := e.RuneAt(c.col)
+		assert.Equal(t, c.want, ok, "col %d", c.col)
+		assert.Equal(t, c.r, sr.Rune, "col %d", c.col)
+		assert.Equal(t, c.sgr, sr.SGR(), "col %d", c.col)
+	}
+
+	_, ok := NewEntry("").RuneAt(0)
+	assert.False(t, ok)
+}
@@ -26,10 +26,21 @@ import (
 //	(8, 4): start from 8, and 4 columns wide
 //	  for "01三五七89" it returns "89  " (pad two space at end)
 //	  for "0123456789" it returns "89  " (pad two space at end)
+//
+// If the viewport covers no character of the entry, it returns width spaces.
 func (e *Entry) StyledMove(startCol, width int) string {
+	return e.StyledMovePad(startCol, width, ' ')
+}
+
+// StyledMovePad is like StyledMove, but pads with pad instead of spaces, which
+// is useful to draw over a patterned background.
+//
+// If pad is a wide rune and odd number of columns must be filled, the last
+// column is filled with a space.
+func (e *Entry) StyledMovePad(startCol, width int, pad rune) string {
 	// algo:
-	//   1. compute if we have to pad spaces at beginning (startCol < 0)
-	//   2. compute if we have to pad spaces at end (startCol+width > entry width)
+	//   1. compute if we have to pad at beginning (startCol < 0)
+	//   2. compute if we have to pad at end (startCol+width > entry width)
 	//   3. compute the substring to extract from the entry
 	//   4. combine them together
 	buf := &strings.Builder{}
@@ -37,6 +48,11 @@ func (e *Entry) StyledMove(startCol, width int) string {
 	prefix := 0
 	suffix := 0
 
+	if startCol >= totalWidth || startCol+width <= 0 {
+		// viewport does not cover any character
+		return padding(max(0, width), pad)
+	}
+
 	if startCol < 0 {
 		prefix = -startCol
 		startCol = 0
@@ -49,16 +65,45 @@ func (e *Entry) StyledMove(startCol, width int) string {
 
 	str := e.StyledShift(startCol, width)
 	buf.Grow(len(str) + prefix + suffix)
-	for i := 0; i < prefix; i++ {
-		buf.WriteRune(' ')
-	}
+	buf.WriteString(padding(prefix, pad))
 	buf.WriteString(str)
-	for i := 0; i < suffix; i++ {
-		buf.WriteRune(' ')
-	}
+	buf.WriteString(padding(suffix, pad))
 	return buf.String()
 }
 
+// StyledWindow returns exactly columns [startCol, startCol+width) of the
+// entry. Unlike StyledShift, the window is not clamped into the entry, columns
+// out of the entry are filled with spaces. For example, (20, 10) of a 15
+// columns wide entry returns 10 spaces.
+//
+// It is like StyledMove, but a wide rune cut by the window is always replaced
+// by a space, so the result is never wider than width.
+func (e *Entry) StyledWindow(startCol, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	s := e.Slice(startCol, startCol+width)
+	prefix := min(width, max(0, -startCol))
+	suffix := width - prefix - s.Width()
+	return strings.Repeat(" ", prefix) + s.StyledString() + strings.Repeat(" ", suffix)
+}
+
+// padding fills n columns with pad
+func padding(n int, pad rune) string {
+	if n <= 0 {
+		return ""
+	}
+	if RuneWidth(pad) < 2 {
+		return strings.Repeat(string(pad), n)
+	}
+
+	ret := strings.Repeat(string(pad), n/2)
+	if n%2 == 1 {
+		ret += " "
+	}
+	return ret
+}
+
 // StyledShift returns a styled substring of the entry, starting at startCol
 //
 // startCol and width are in terms of display width, not string length. For
@@ -78,6 +123,44 @@ func (e *Entry) StyledShift(startCol, width int) string {
 	ret, _, _ := e.styledShift(startCol, width)
 	return ret
 }
+
+// StyledShiftEx is like StyledShift, but also reports whether a wide character
+// is cut at left or right edge of the window, which is replaced by a space.
+func (e *Entry) StyledShiftEx(startCol, width int) (s string, cutLeft, cutRight bool) {
+	return e.styledShift(startCol, width)
+}
+
+// Slice returns a new entry containing runes in display columns
+// [startCol, endCol). Like StyledShift, each column of a wide rune cut by the
+// window is replaced by a space. The window is clipped to the entry, so the
+// result might be narrower than endCol-startCol.
+func (e *Entry) Slice(startCol, endCol int) *Entry {
+	startCol, endCol = max(0, startCol), min(endCol, e.Width())
+	if startCol >= endCol {
+		return newEntry(nil)
+	}
+
+	offsets := e.runeEndOffsets()
+	i := sort.Search(len(offsets), func(i int) bool { return offsets[i] > startCol })
+	data := make([]StyledRune, 0, endCol-startCol)
+	for ; i < len(e.styledData); i++ {
+		sr := e.styledData[i]
+		end := offsets[i]
+		begin := end - sr.width()
+		if begin >= endCol {
+			break
+		}
+		if begin >= startCol && end <= endCol {
+			data = append(data, sr)
+			continue
+		}
+		for c := max(begin, startCol); c < min(end, endCol); c++ {
+			data = append(data, StyledRune{Rune: ' '})
+		}
+	}
+	return newEntry(data)
+}
+
 func (e *Entry) styledShift(startCol, width int) (string, bool, bool) {
 	if len(e.styledData) == 0 {
 		return "", false, false
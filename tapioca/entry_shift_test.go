@@ -281,3 +281,87 @@ func TestEntry_ComputeStartAndEndForShift(t *testing.T) {
 		})
 	}
 }
+
+func TestEntry_StyledShiftEx(t *testing.T) {
+	cases := []struct {
+		name         string
+		start, width int
+		expect       string
+		left, right  bool
+	}{
+		{name: "no cut", start: 0, width: 4, expect: "01三"},
+		{name: "cut right", start: 1, width: 4, expect: "1三 ", right: true},
+		{name: "cut left", start: 3, width: 3, expect: " 五", left: true},
+		{name: "cut both", start: 3, width: 4, expect: " 五 ", left: true, right: true},
+		{name: "whole", start: 0, width: 100, expect: "01三五七89"},
+	}
+
+	e := NewEntry("01三五七89")
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, left, right := e.StyledShiftEx(c.start, c.width)
+			assert.Equal(t, c.expect, s)
+			assert.Equal(t, c.left, left)
+			assert.Equal(t, c.right, right)
+			assert.Equal(t, e.StyledShift(c.start, c.width), s)
+		})
+	}
+}
+
+func TestEntry_Slice(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		start    int
+		end      int
+		expected string
+		width    int
+	}{
+		{name: "plain", input: "0123456789", start: 3, end: 6, expected: "345", width: 3},
+		{name: "styled", input: "012\x1b[31m345\x1b[0m6789", start: 4, end: 7, expected: "\x1b[31m45\x1b[0m6", width: 3},
+		{name: "wide", input: "01三五七89", start: 2, end: 6, expected: "三五", width: 4},
+		{name: "cut both sides", input: "01三五七89", start: 3, end: 7, expected: " 五 ", width: 4},
+		{name: "inside wide rune", input: "01三五七89", start: 2, end: 3, expected: " ", width: 1},
+		{name: "clipped", input: "0123", start: -2, end: 10, expected: "0123", width: 4},
+		{name: "out of range", input: "0123", start: 5, end: 10, expected: "", width: 0},
+		{name: "empty window", input: "0123", start: 2, end: 2, expected: "", width: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEntry(c.input)
+			got := e.Slice(c.start, c.end)
+			assert.Equal(t, c.expected, got.StyledString())
+			assert.Equal(t, c.width, got.Width())
+		})
+	}
+}
+
+func TestEntry_StyledWindow(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		start    int
+		width    int
+		expected string
+	}{
+		{name: "inside", input: "0123456789", start: 3, width: 3, expected: "345"},
+		{name: "past end", input: "0123456789abcde", start: 20, width: 10, expected: "          "},
+		{name: "partly past end", input: "0123456789", start: 8, width: 4, expected: "89  "},
+		{name: "before start", input: "0123", start: -2, width: 4, expected: "  01"},
+		{name: "far before start", input: "0123", start: -10, width: 4, expected: "    "},
+		{name: "styled", input: "\x1b[31m0123\x1b[0m", start: 2, width: 4, expected: "\x1b[31m23\x1b[0m  "},
+		{name: "cut wide", input: "01三五七89", start: 3, width: 4, expected: " 五 "},
+		{name: "inside wide rune", input: "01三五七89", start: 2, width: 1, expected: " "},
+		{name: "zero width", input: "0123", start: 0, width: 0, expected: ""},
+		{name: "empty entry", input: "", start: 0, width: 3, expected: "   "},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := NewEntry(c.input).StyledWindow(c.start, c.width)
+			assert.Equal(t, c.expected, got)
+			assert.Equal(t, c.width, NewEntry(got).Width())
+		})
+	}
+}
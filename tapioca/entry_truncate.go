@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"sort"
+	"strings"
+)
+
+// TruncateLeft returns the styled entry if it fits in width columns. Otherwise
+// the head is cut and ellipsis is prepended, so the result keeps the tail of
+// the entry, which is useful for file paths:
+//
+//	"/very/long/path" with (10, "…") returns "…long/path"
+//
+// The ellipsis can be styled. Result might be 1 column narrower than width if a
+// wide character is at the cut point. If width is not enough to hold the
+// ellipsis, the ellipsis is truncated.
+func (e *Entry) TruncateLeft(width int, ellipsis string) string {
+	if width <= 0 {
+		return ""
+	}
+	if !e.WidthExceeds(width) {
+		return e.StyledString()
+	}
+
+	el := NewEntry(ellipsis)
+	keep := width - el.Width()
+	if keep <= 0 {
+		return el.StyledShift(0, width)
+	}
+
+	// first rune which makes the tail fit in keep columns
+	offsets := e.runeEndOffsets()
+	total := offsets[len(offsets)-1]
+	idx := sort.Search(len(offsets), func(i int) bool {
+		return total-offsets[i] <= keep
+	})
+	// rune at idx is the last one to cut
+	return el.StyledString() + e.styledSubstring(idx+1, len(offsets))
+}
+
+// StyledTruncate returns the styled entry exactly width columns wide. Longer
+// entry is cut and ellipsis is appended, shorter one is padded with spaces.
+//
+// If a wide character is at the cut point, it is replaced by a space. If width
+// is not enough to hold the ellipsis, it returns spaces.
+func (e *Entry) StyledTruncate(width int, ellipsis rune) string {
+	if width <= 0 {
+		return ""
+	}
+	if !e.WidthExceeds(width) {
+		return e.StyledMove(0, width)
+	}
+
+	keep := width - RuneWidth(ellipsis)
+	switch {
+	case keep < 0:
+		return strings.Repeat(" ", width)
+	case keep == 0:
+		return string(ellipsis)
+	case keep == 1 && e.runeEndOffsets()[0] > 1:
+		// StyledShift keeps the wide rune if it is the only one
+		return " " + string(ellipsis)
+	}
+	return e.StyledShift(0, keep) + string(ellipsis)
+}
@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_TruncateLeft(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		width    int
+		ellipsis string
+		expected string
+	}{
+		{name: "fits", input: "/a/b", width: 10, ellipsis: "…", expected: "/a/b"},
+		{name: "exact", input: "/a/b", width: 4, ellipsis: "…", expected: "/a/b"},
+		{name: "path", input: "/very/long/path", width: 10, ellipsis: "…", expected: "…long/path"},
+		{name: "multi-column ellipsis", input: "/very/long/path", width: 10, ellipsis: "...", expected: "...ng/path"},
+		{name: "wide char at cut", input: "三五七九", width: 4, ellipsis: "…", expected: "…九"},
+		{name: "wide char", input: "三五七九", width: 5, ellipsis: "…", expected: "…七九"},
+		{
+			name:     "styles",
+			input:    "\x1b[31m0123\x1b[0m456",
+			width:    5,
+			ellipsis: "…",
+			expected: "…\x1b[31m3\x1b[0m456",
+		},
+		{
+			name:     "styled ellipsis",
+			input:    "0123456",
+			width:    4,
+			ellipsis: "\x1b[2m…\x1b[0m",
+			expected: "\x1b[2m…\x1b[0m456",
+		},
+		{name: "no room for tail", input: "0123456", width: 2, ellipsis: "...", expected: ".."},
+		{name: "zero width", input: "0123456", width: 0, ellipsis: "…", expected: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := NewEntry(c.input).TruncateLeft(c.width, c.ellipsis)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestEntry_StyledTruncate(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		width    int
+		ellipsis rune
+		expected string
+	}{
+		{name: "pad", input: "abc", width: 5, ellipsis: '…', expected: "abc  "},
+		{name: "exact", input: "abcde", width: 5, ellipsis: '…', expected: "abcde"},
+		{name: "cut", input: "abcdefg", width: 5, ellipsis: '…', expected: "abcd…"},
+		{name: "wide char at cut", input: "中文字", width: 4, ellipsis: '…', expected: "中 …"},
+		{name: "wide ellipsis", input: "abcdefg", width: 5, ellipsis: '省', expected: "abc省"},
+		{name: "wide char only", input: "中文字", width: 2, ellipsis: '…', expected: " …"},
+		{
+			name:     "styles",
+			input:    "\x1b[31mabc\x1b[0mdefg",
+			width:    3,
+			ellipsis: '…',
+			expected: "\x1b[31mab\x1b[0m…",
+		},
+		{name: "only ellipsis", input: "abc", width: 1, ellipsis: '~', expected: "~"},
+		{name: "no room", input: "abc", width: 1, ellipsis: '省', expected: " "},
+		{name: "zero width", input: "abc", width: 0, ellipsis: '…', expected: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := NewEntry(c.input).StyledTruncate(c.width, c.ellipsis)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
@@ -4,7 +4,10 @@
 
 package tapioca
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 type warpPoint struct {
 	start     int
@@ -23,15 +26,19 @@ func (e *Entry) computeWarpPoints(width int) []warpPoint {
 		return []warpPoint{{0, n, false}}
 	}
 
-	startPos := 0
+	return computeHardWarpPoints(offsets, 0, w, width)
+}
 
-	ret := make([]warpPoint, 0, w/width+1)
+// computeHardWarpPoints splits columns [startPos, endPos) into lines of at most
+// width columns, regardless of words.
+func computeHardWarpPoints(offsets []int, startPos, endPos, width int) []warpPoint {
+	ret := make([]warpPoint, 0, (endPos-startPos)/width+1)
 
-	for startPos < w {
+	for startPos < endPos {
 		start, end, _, hasSuffix := computeStartAndEndForShift(
 			offsets,
 			startPos,
-			width,
+			min(width, endPos-startPos),
 		)
 		realWidth := offsets[end-1] - offsets[start] + computeRuneSizeFromOffset(offsets, start)
 		if hasSuffix {
@@ -87,12 +94,25 @@ func (e *Entry) substringWidth(start, end int) int {
 //
 // For width == 1 with wide characters, the lines contain wide character will have a
 // width of 2.
+//
+// The result is cached for the last width, so repeated calls are cheap. The
+// returned slice must not be modified.
 func (e *Entry) StyledBlock(width int) []string {
 	if len(e.styledData) < 1 {
 		return []string{strings.Repeat(" ", max(1, width))}
 	}
 
 	width = max(1, width)
+	e.blockLock.Lock()
+	defer e.blockLock.Unlock()
+	if e.block == nil || e.blockWidth != width {
+		e.block, e.blockWidth = e.styledBlock(width), width
+	}
+	// appending to it should not touch the cache
+	return slices.Clip(e.block)
+}
+
+func (e *Entry) styledBlock(width int) []string {
 	points := e.computeWarpPoints(width)
 	ret := make([]string, 0, len(points))
 	for _, p := range points[:len(points)-1] {
@@ -108,7 +108,7 @@ var warpCases = []struct {
 		input: "\x1b[31mRed\x1b[34mBlue\x1b[0mNormal\x1b[32mGreen",
 		width: 5,
 		expected: []string{
-			"\x1b[31mRed\x1b[0m\x1b[34mBl\x1b[0m",
+			"\x1b[31mRed\x1b[34mBl\x1b[0m",
 			"\x1b[34mue\x1b[0mNor",
 			"mal\x1b[32mGr\x1b[0m",
 			"\x1b[32meen\x1b[0m",
@@ -149,7 +149,7 @@ var warpCases = []struct {
 		input: "\x1b[31mA一\x1b[34mB二C\x1b[m三D",
 		width: 4,
 		expected: []string{
-			"\x1b[31mA一\x1b[0m\x1b[34mB\x1b[0m",
+			"\x1b[31mA一\x1b[34mB\x1b[0m",
 			"\x1b[34m二C\x1b[0m ",
 			"三D",
 		},
@@ -201,6 +201,29 @@ func TestEntry_StyledBlock(t *testing.T) {
 	}
 }
 
+func TestEntry_StyledBlockCache(t *testing.T) {
+	e := NewEntry("\x1b[31mabcdef\x1b[0mgh")
+	first := e.StyledBlock(3)
+	second := e.StyledBlock(3)
+	assert.Equal(t, []string{"\x1b[31mabc\x1b[0m", "\x1b[31mdef\x1b[0m", "gh "}, second)
+	assert.Same(t, &first[0], &second[0], "cached")
+
+	// appending to result does not affect the cache
+	_ = append(first, "x")
+	assert.Len(t, e.StyledBlock(3), 3)
+
+	// recomputed when width changes
+	assert.Equal(t, []string{"\x1b[31mabcd\x1b[0m", "\x1b[31mef\x1b[0mgh"}, e.StyledBlock(4))
+	assert.Equal(t, second, e.StyledBlock(3))
+}
+
+func BenchmarkEntry_StyledBlock(b *testing.B) {
+	e := NewPlainEntry(strings.Repeat("log line 日誌 ", 100))
+	for b.Loop() {
+		_ = e.StyledBlock(80)
+	}
+}
+
 func TestEntry_SubstringWidth(t *testing.T) {
 	cases := []struct {
 		name     string
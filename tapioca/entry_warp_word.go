@@ -0,0 +1,166 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WrapPolicy controls where an entry is broken into lines when wrapping.
+type WrapPolicy int
+
+const (
+	// BreakAnywhere breaks lines at exact width, even in the middle of a word.
+	// It is the behavior of [Entry.StyledBlock].
+	BreakAnywhere WrapPolicy = iota
+	// BreakWord breaks lines at spaces. Words longer than the width are broken
+	// like BreakAnywhere.
+	BreakWord
+	// Overflow breaks lines at spaces. Words longer than the width are kept in
+	// a single line, which is wider than the width.
+	Overflow
+)
+
+// computeWarpPointsWith is computeWarpPoints with configurable wrap policy.
+//
+// Spaces at the line breaks are dropped, but leading spaces of the entry are
+// preserved as indentation.
+func (e *Entry) computeWarpPointsWith(width int, policy WrapPolicy) []warpPoint {
+	if policy == BreakAnywhere || width < 1 || e.Width() <= width {
+		return e.computeWarpPoints(width)
+	}
+
+	offsets := e.runeEndOffsets()
+	n := len(offsets)
+	// start column of rune at idx
+	colOf := func(idx int) int {
+		if idx == 0 {
+			return 0
+		}
+		return offsets[idx-1]
+	}
+	isSpace := func(idx int) bool {
+		return unicode.IsSpace(e.styledData[idx].Rune)
+	}
+
+	ret := make([]warpPoint, 0, e.Width()/width+1)
+	lineStart, lineEnd := 0, 0 // rune index of current line, lineEnd is exclusive
+	hasWord := false           // current line has at least one word
+	idx := 0
+	for idx < n && isSpace(idx) {
+		idx++
+	}
+	lineEnd = idx
+	if idx == n {
+		// spaces only
+		return e.computeWarpPoints(width)
+	}
+
+	for idx < n {
+		wordStart := idx
+		for idx < n && !isSpace(idx) {
+			idx++
+		}
+		wordEnd := idx
+		for idx < n && isSpace(idx) {
+			idx++
+		}
+
+		for {
+			if colOf(wordEnd)-colOf(lineStart) <= width {
+				lineEnd = wordEnd
+				hasWord = true
+				break
+			}
+			if hasWord {
+				// start a new line with this word
+				ret = append(ret, warpPoint{lineStart, lineEnd, false})
+				lineStart, lineEnd = wordStart, wordStart
+				hasWord = false
+				continue
+			}
+
+			// this word is too long to fit in a line
+			if policy == Overflow {
+				ret = append(ret, warpPoint{lineStart, wordEnd, false})
+				lineStart, lineEnd = idx, idx
+				break
+			}
+			points := computeHardWarpPoints(offsets, colOf(lineStart), colOf(wordEnd), width)
+			ret = append(ret, points[:len(points)-1]...)
+			last := points[len(points)-1]
+			lineStart, lineEnd = last.start, last.end
+			hasWord = true
+			break
+		}
+	}
+	if hasWord {
+		ret = append(ret, warpPoint{lineStart, lineEnd, false})
+	}
+
+	return ret
+}
+
+// LinesWith is like Lines, but wraps the entry with the given policy.
+func (e *Entry) LinesWith(width int, policy WrapPolicy) int {
+	return len(e.computeWarpPointsWith(max(1, width), policy))
+}
+
+// Wrap splits the entry into lines using the given policy, each line is a new
+// Entry sharing the same underlying data.
+//
+// For width <= 0, it resets to 1.
+//
+// With Overflow policy, lines contain a long word are wider than width.
+func (e *Entry) Wrap(width int, policy WrapPolicy) []*Entry {
+	if len(e.styledData) < 1 {
+		return []*Entry{e}
+	}
+
+	points := e.computeWarpPointsWith(max(1, width), policy)
+	ret := make([]*Entry, 0, len(points))
+	for _, p := range points {
+		ret = append(ret, e.sub(p.start, p.end))
+	}
+	return ret
+}
+
+// StyledBlockWith is like StyledBlock, but wraps the entry with the given
+// policy. Every line is padded with spaces to width.
+//
+// With Overflow policy, lines contain a long word are wider than width.
+func (e *Entry) StyledBlockWith(width int, policy WrapPolicy) []string {
+	if policy == BreakAnywhere {
+		return e.StyledBlock(width)
+	}
+
+	width = max(1, width)
+	lines := e.Wrap(width, policy)
+	ret := make([]string, 0, len(lines))
+	for _, l := range lines {
+		str := l.StyledString()
+		w := l.Width()
+		if w >= width {
+			ret = append(ret, str)
+			continue
+		}
+
+		buf := strings.Builder{}
+		buf.Grow(len(str) + width - w)
+		buf.WriteString(str)
+		for range width - w {
+			buf.WriteByte(' ')
+		}
+		ret = append(ret, buf.String())
+	}
+	return ret
+}
+
+// sub creates a new Entry containing runes in [start, end), sharing the same
+// underlying data.
+func (e *Entry) sub(start, end int) *Entry {
+	return newEntry(e.styledData[start:end:end])
+}
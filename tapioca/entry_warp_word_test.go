@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_StyledBlockWith(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		width    int
+		policy   WrapPolicy
+		expected []string
+	}{
+		{
+			name:     "break anywhere is StyledBlock",
+			input:    "hello world",
+			width:    8,
+			policy:   BreakAnywhere,
+			expected: []string{"hello wo", "rld     "},
+		},
+		{
+			name:     "break word",
+			input:    "hello world",
+			width:    8,
+			policy:   BreakWord,
+			expected: []string{"hello   ", "world   "},
+		},
+		{
+			name:     "break word, fits",
+			input:    "hello world",
+			width:    11,
+			policy:   BreakWord,
+			expected: []string{"hello world"},
+		},
+		{
+			name:     "break word, multiple words per line",
+			input:    "a bb ccc dd e",
+			width:    6,
+			policy:   BreakWord,
+			expected: []string{"a bb  ", "ccc dd", "e     "},
+		},
+		{
+			name:     "break word, keeps indentation",
+			input:    "  ab cd",
+			width:    5,
+			policy:   BreakWord,
+			expected: []string{"  ab ", "cd   "},
+		},
+		{
+			name:     "break word, long token",
+			input:    "url: https://example.com ok",
+			width:    8,
+			policy:   BreakWord,
+			expected: []string{"url:    ", "https://", "example.", "com ok  "},
+		},
+		{
+			name:     "break word, long token with wide characters",
+			input:    "a 一二三四五",
+			width:    5,
+			policy:   BreakWord,
+			expected: []string{"a    ", "一二 ", "三四 ", "五   "},
+		},
+		{
+			name:     "break word, with style",
+			input:    "\x1b[31mred\x1b[m text",
+			width:    4,
+			policy:   BreakWord,
+			expected: []string{"\x1b[31mred\x1b[0m ", "text"},
+		},
+		{
+			name:     "break word, spaces only",
+			input:    "      ",
+			width:    4,
+			policy:   BreakWord,
+			expected: []string{"    ", "    "},
+		},
+		{
+			name:     "overflow, long token",
+			input:    "url: https://example.com ok",
+			width:    8,
+			policy:   Overflow,
+			expected: []string{"url:    ", "https://example.com", "ok      "},
+		},
+		{
+			name:     "overflow, long token at beginning",
+			input:    "0123456789 ok",
+			width:    5,
+			policy:   Overflow,
+			expected: []string{"0123456789", "ok   "},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := NewEntry(c.input)
+			assert.Equal(t, c.expected, e.StyledBlockWith(c.width, c.policy))
+			assert.Equal(t, len(c.expected), e.LinesWith(c.width, c.policy))
+			assert.Equal(t, len(c.expected), len(e.Wrap(c.width, c.policy)))
+		})
+	}
+}
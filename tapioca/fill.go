@@ -0,0 +1,19 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "strings"
+
+// FillRune fills unoccupied regions, like empty grid cells, padding layouts and
+// blank rows of components. Default to space.
+//
+// Set it to a visible rune like '·' during development to see which regions
+// are not occupied. It must be 1 column wide.
+var FillRune = ' '
+
+// BlankLine returns a line of width columns filled with FillRune.
+func BlankLine(width int) string {
+	return strings.Repeat(string(FillRune), max(0, width))
+}
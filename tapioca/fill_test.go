@@ -0,0 +1,20 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlankLine(t *testing.T) {
+	assert.Equal(t, "   ", BlankLine(3))
+	assert.Equal(t, "", BlankLine(-1))
+
+	defer func(r rune) { FillRune = r }(FillRune)
+	FillRune = '·'
+	assert.Equal(t, "···", BlankLine(3))
+}
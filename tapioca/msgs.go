@@ -4,6 +4,16 @@
 
 package tapioca
 
+import tea "github.com/charmbracelet/bubbletea"
+
+// BatchMsg carries multiple messages which are applied as a whole. Components
+// supporting it apply all messages, then recompute cached info only once.
+//
+// It is useful to load a large amount of content without recomputing after
+// every message. Components which do not support it ignore the whole batch,
+// check document of the component before using it.
+type BatchMsg []tea.Msg
+
 // ResizeMsg denotes the layout component is asking target to resize
 type ResizeMsg struct {
 	Width  int
@@ -28,12 +38,27 @@ type ScrollTopMsg struct{}
 // ScrollBottomMsg tells the component to scroll to the bottom of the component
 type ScrollBottomMsg struct{}
 
+// ScrollPageUpMsg tells the component to scroll up by a page, which is height
+// of the viewport minus 1 row
+type ScrollPageUpMsg struct{}
+
+// ScrollPageDownMsg tells the component to scroll down by a page, which is
+// height of the viewport minus 1 row
+type ScrollPageDownMsg struct{}
+
 // ScrollBeginMsg tells the component to scroll to the beginning of the line
 type ScrollBeginMsg struct{}
 
 // ScrollEndMsg tells the component to scroll to the end of the line
 type ScrollEndMsg struct{}
 
+// ScrollByMsg tells the component to scroll by the specified columns and rows,
+// negative values scroll left/up
+type ScrollByMsg struct {
+	X int
+	Y int
+}
+
 // ScrollToMsg tells the component to scroll to the specified column and row
 type ScrollToMsg struct {
 	X int
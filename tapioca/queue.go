@@ -4,6 +4,8 @@
 
 package tapioca
 
+import "iter"
+
 // CircularBuffer is a generic circular buffer implementation.
 type CircularBuffer[T any] struct {
 	data       []T
@@ -23,6 +25,18 @@ func NewCircularBuffer[T any](size int) *CircularBuffer[T] {
 	}
 }
 
+// Clone returns a copy of the buffer, modifying one does not affect the other.
+// Elements are copied by value, so pointers are shared.
+func (cb *CircularBuffer[T]) Clone() *CircularBuffer[T] {
+	data := make([]T, len(cb.data))
+	copy(data, cb.data)
+	return &CircularBuffer[T]{
+		data:  data,
+		start: cb.start,
+		end:   cb.end,
+	}
+}
+
 // Reset clears the buffer
 func (cb *CircularBuffer[T]) Reset() {
 	cb.start = 0
@@ -40,6 +54,25 @@ func (cb *CircularBuffer[T]) Append(item T) {
 	cb.end = newEnd
 }
 
+// AppendAll adds items to the end of the buffer in order, removing oldest
+// items if full. The result is identical to calling Append for each item, but
+// a large batch is copied at once.
+func (cb *CircularBuffer[T]) AppendAll(items ...T) {
+	n := len(cb.data)
+	if c := n - 1; len(items) > c {
+		// only newest items are kept
+		items = items[len(items)-c:]
+	}
+	overflow := cb.Size() + len(items) - (n - 1)
+
+	k := copy(cb.data[cb.end:], items)
+	copy(cb.data, items[k:])
+	cb.end = (cb.end + len(items)) % n
+	if overflow > 0 {
+		cb.start = (cb.start + overflow) % n
+	}
+}
+
 // Prepend adds an item to the start of the buffer, removing the oldest item if full
 func (cb *CircularBuffer[T]) Prepend(item T) {
 	cb.start = (cb.start - 1 + len(cb.data)) % len(cb.data)
@@ -50,19 +83,85 @@ func (cb *CircularBuffer[T]) Prepend(item T) {
 	}
 }
 
-// GetAll returns all elements in the buffer in order from oldest to newest
+// PopFront removes and returns the first item, false if the buffer is empty
+func (cb *CircularBuffer[T]) PopFront() (item T, ok bool) {
+	if cb.start == cb.end {
+		return
+	}
+
+	var zero T
+	item = cb.data[cb.start]
+	cb.data[cb.start] = zero
+	cb.start = (cb.start + 1) % len(cb.data)
+	return item, true
+}
+
+// PopBack removes and returns the last item, false if the buffer is empty
+func (cb *CircularBuffer[T]) PopBack() (item T, ok bool) {
+	if cb.start == cb.end {
+		return
+	}
+
+	var zero T
+	cb.end = (cb.end - 1 + len(cb.data)) % len(cb.data)
+	item = cb.data[cb.end]
+	cb.data[cb.end] = zero
+	return item, true
+}
+
+// GetAll returns all elements in the buffer in order from oldest to newest.
+// The returned slice is a copy, use All to iterate without allocating.
 func (cb *CircularBuffer[T]) GetAll() []T {
 	if cb.start == cb.end {
 		return nil
 	}
 
-	var result []T
-	if cb.end > cb.start {
-		result = cb.data[cb.start:cb.end]
-	} else {
-		result = append(cb.data[cb.start:], cb.data[:cb.end]...)
+	ret := make([]T, 0, cb.Size())
+	for _, item := range cb.All() {
+		ret = append(ret, item)
+	}
+	return ret
+}
+
+// All returns an iterator over index and element in order from oldest to
+// newest. Modifying the buffer while iterating leads to undefined result.
+func (cb *CircularBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		n := len(cb.data)
+		for i, idx := 0, cb.start; idx != cb.end; i, idx = i+1, (idx+1)%n {
+			if !yield(i, cb.data[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// At returns the i-th oldest element, false if i is out of range. Unlike
+// GetAll, it does not allocate.
+func (cb *CircularBuffer[T]) At(i int) (item T, ok bool) {
+	if i < 0 || i >= cb.Size() {
+		return
+	}
+	return cb.data[(cb.start+i)%len(cb.data)], true
+}
+
+// PeekLast returns up to n newest elements in order from oldest to newest,
+// without removing them. The returned slice is a copy.
+func (cb *CircularBuffer[T]) PeekLast(n int) []T {
+	n = min(n, cb.Size())
+	if n <= 0 {
+		return nil
+	}
+
+	ret := make([]T, n)
+	first := (cb.end - n + len(cb.data)) % len(cb.data)
+	if first < cb.end {
+		copy(ret, cb.data[first:cb.end])
+		return ret
 	}
-	return result
+	k := copy(ret, cb.data[first:])
+	copy(ret[k:], cb.data[:cb.end])
+	return ret
 }
 
 // Size returns the number of elements currently in the buffer
@@ -78,7 +177,9 @@ func (cb *CircularBuffer[T]) Capacity() int {
 	return len(cb.data) - 1
 }
 
-// Resize changes the capacity of the buffer, preserving existing elements
+// Resize changes the capacity of the buffer, preserving existing elements. If
+// the buffer is shrunk below its size, oldest elements are removed, just like
+// appending to a full buffer.
 func (cb *CircularBuffer[T]) Resize(newSize int) {
 	if newSize < 1 {
 		newSize = 1
@@ -92,8 +193,7 @@ func (cb *CircularBuffer[T]) Resize(newSize int) {
 	arr := cb.GetAll()
 	l := len(arr)
 	newData := make([]T, newSize+1)
-	end := min(l, newSize)
-	copy(newData, arr[:end])
+	end := copy(newData, arr[max(0, l-newSize):])
 	cb.data = newData
 	cb.start = 0
 	cb.end = end
@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import "sync"
+
+// SyncCircularBuffer is a [CircularBuffer] guarded by a sync.RWMutex, so it
+// can be shared between goroutines, like a job goroutine writing logs while
+// the Tea goroutine renders them.
+//
+// Every call takes the lock, which costs tens of nanoseconds even without
+// contention, and writers block readers. Components use CircularBuffer and
+// expect to be accessed only on the Tea goroutine, prefer sending messages to
+// them over sharing a buffer.
+type SyncCircularBuffer[T any] struct {
+	lock sync.RWMutex
+	cb   *CircularBuffer[T]
+}
+
+// NewSyncCircularBuffer creates a new SyncCircularBuffer with the given size.
+func NewSyncCircularBuffer[T any](size int) *SyncCircularBuffer[T] {
+	return &SyncCircularBuffer[T]{cb: NewCircularBuffer[T](size)}
+}
+
+// Reset clears the buffer
+func (s *SyncCircularBuffer[T]) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cb.Reset()
+}
+
+// Append adds an item to the end of the buffer, removing the oldest item if full
+func (s *SyncCircularBuffer[T]) Append(item T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cb.Append(item)
+}
+
+// AppendAll adds items to the end of the buffer in order, removing oldest
+// items if full. Other goroutines see either none or all of the items.
+func (s *SyncCircularBuffer[T]) AppendAll(items ...T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cb.AppendAll(items...)
+}
+
+// Prepend adds an item to the start of the buffer, removing the oldest item if full
+func (s *SyncCircularBuffer[T]) Prepend(item T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cb.Prepend(item)
+}
+
+// PopFront removes and returns the first item, false if the buffer is empty
+func (s *SyncCircularBuffer[T]) PopFront() (item T, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.cb.PopFront()
+}
+
+// PopBack removes and returns the last item, false if the buffer is empty
+func (s *SyncCircularBuffer[T]) PopBack() (item T, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.cb.PopBack()
+}
+
+// GetAll returns a copy of all elements in order from oldest to newest.
+func (s *SyncCircularBuffer[T]) GetAll() []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cb.GetAll()
+}
+
+// At returns the i-th oldest element, false if i is out of range.
+func (s *SyncCircularBuffer[T]) At(i int) (item T, ok bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cb.At(i)
+}
+
+// PeekLast returns a copy of up to n newest elements in order from oldest to
+// newest.
+func (s *SyncCircularBuffer[T]) PeekLast(n int) []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cb.PeekLast(n)
+}
+
+// Size returns the number of elements currently in the buffer
+func (s *SyncCircularBuffer[T]) Size() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cb.Size()
+}
+
+// Capacity returns the maximum number of elements the buffer can hold
+func (s *SyncCircularBuffer[T]) Capacity() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cb.Capacity()
+}
+
+// Resize changes the capacity of the buffer, see [CircularBuffer.Resize].
+func (s *SyncCircularBuffer[T]) Resize(newSize int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cb.Resize(newSize)
+}
@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncCircularBuffer(t *testing.T) {
+	s := NewSyncCircularBuffer[int](3)
+	s.Append(1)
+	s.AppendAll(2, 3, 4)
+	s.Prepend(0)
+	assert.Equal(t, []int{0, 2, 3}, s.GetAll())
+	assert.Equal(t, 3, s.Size())
+	assert.Equal(t, 3, s.Capacity())
+
+	v, ok := s.At(1)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, []int{2, 3}, s.PeekLast(2))
+
+	v, _ = s.PopFront()
+	assert.Equal(t, 0, v)
+	v, _ = s.PopBack()
+	assert.Equal(t, 3, v)
+
+	s.Resize(5)
+	assert.Equal(t, 5, s.Capacity())
+	s.Reset()
+	assert.Equal(t, 0, s.Size())
+}
+
+// run with -race
+func TestSyncCircularBuffer_Concurrent(t *testing.T) {
+	s := NewSyncCircularBuffer[int](100)
+	wg := &sync.WaitGroup{}
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				if i%10 == 0 {
+					s.AppendAll(w, i)
+					continue
+				}
+				s.Append(i)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			all := s.GetAll()
+			assert.LessOrEqual(t, len(all), s.Capacity())
+			if i%100 == 0 {
+				s.Resize(50 + i%3)
+			}
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, s.Capacity(), s.Size())
+}
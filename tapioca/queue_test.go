@@ -306,7 +306,10 @@ func TestCircularBuffer_Resize(t *testing.T) {
 		cb.Resize(2)
 		assert.Equal(t, 2, cb.Capacity(), "capacity should be updated")
 		assert.Equal(t, 2, cb.Size(), "size should be truncated")
-		assert.Equal(t, []int{1, 2}, cb.GetAll(), "should keep the first elements")
+		assert.Equal(t, []int{3, 4}, cb.GetAll(), "should keep the newest elements")
+
+		cb.Append(5)
+		assert.Equal(t, []int{4, 5}, cb.GetAll(), "should remove oldest element when full")
 	})
 
 	t.Run("shrink buffer larger than current size", func(t *testing.T) {
@@ -358,7 +361,7 @@ func TestCircularBuffer_Resize(t *testing.T) {
 		cb.Resize(0)
 		assert.Equal(t, 1, cb.Capacity(), "capacity should be 1")
 		assert.Equal(t, 1, cb.Size(), "size should be 1")
-		assert.Equal(t, []int{1}, cb.GetAll(), "should keep first element")
+		assert.Equal(t, []int{2}, cb.GetAll(), "should keep newest element")
 	})
 
 	t.Run("resize empty buffer", func(t *testing.T) {
@@ -379,7 +382,7 @@ func TestCircularBuffer_Resize(t *testing.T) {
 		cb.Resize(2)
 		assert.Equal(t, 2, cb.Capacity(), "capacity should be shrunk")
 		assert.Equal(t, 2, cb.Size(), "size should be shrunk")
-		assert.Equal(t, []int{2, 3}, cb.GetAll(), "should keep correct elements after wraparound")
+		assert.Equal(t, []int{3, 4}, cb.GetAll(), "should keep correct elements after wraparound")
 	})
 
 	t.Run("expand buffer with wraparound", func(t *testing.T) {
@@ -400,3 +403,172 @@ func TestCircularBuffer_Resize(t *testing.T) {
 		assert.Equal(t, []int{2, 3, 4, 5, 6}, cb.GetAll())
 	})
 }
+
+func TestCircularBuffer_Pop(t *testing.T) {
+	t.Run("empty buffer", func(t *testing.T) {
+		cb := NewCircularBuffer[int](3)
+		_, ok := cb.PopFront()
+		assert.False(t, ok, "should fail on empty buffer")
+		_, ok = cb.PopBack()
+		assert.False(t, ok, "should fail on empty buffer")
+	})
+
+	t.Run("pop from both ends of wrapped buffer", func(t *testing.T) {
+		cb := NewCircularBuffer[int](3)
+		for i := 1; i <= 5; i++ {
+			cb.Append(i)
+		}
+
+		v, ok := cb.PopFront()
+		assert.True(t, ok)
+		assert.Equal(t, 3, v, "should pop the oldest element")
+		v, ok = cb.PopBack()
+		assert.True(t, ok)
+		assert.Equal(t, 5, v, "should pop the newest element")
+		assert.Equal(t, []int{4}, cb.GetAll())
+
+		cb.Append(6)
+		cb.Prepend(7)
+		assert.Equal(t, []int{7, 4, 6}, cb.GetAll(), "should work normally after pop")
+	})
+}
+
+func TestCircularBuffer_At(t *testing.T) {
+	cb := NewCircularBuffer[int](3)
+	_, ok := cb.At(0)
+	assert.False(t, ok, "should fail on empty buffer")
+
+	// wrapped: start is after end in underlying array
+	for i := 1; i <= 5; i++ {
+		cb.Append(i)
+	}
+	for i, want := range cb.GetAll() {
+		v, ok := cb.At(i)
+		assert.True(t, ok)
+		assert.Equal(t, want, v, "index %d", i)
+	}
+	_, ok = cb.At(3)
+	assert.False(t, ok)
+	_, ok = cb.At(-1)
+	assert.False(t, ok)
+
+	cb.Prepend(0)
+	v, _ := cb.At(0)
+	assert.Equal(t, 0, v)
+}
+
+func TestCircularBuffer_PeekLast(t *testing.T) {
+	cb := NewCircularBuffer[int](4)
+	assert.Nil(t, cb.PeekLast(2), "empty buffer")
+
+	cb.Append(1)
+	cb.Append(2)
+	assert.Equal(t, []int{2}, cb.PeekLast(1))
+	assert.Equal(t, []int{1, 2}, cb.PeekLast(5), "at most size elements")
+	assert.Nil(t, cb.PeekLast(0))
+
+	for i := 3; i <= 7; i++ {
+		cb.Append(i)
+		all := cb.GetAll()
+		assert.Equal(t, all[len(all)-3:], cb.PeekLast(3), "after appending %d", i)
+		assert.Equal(t, cb.GetAll(), cb.PeekLast(4), "after appending %d", i)
+	}
+
+	got := cb.PeekLast(2)
+	got[0] = 100
+	assert.Equal(t, []int{4, 5, 6, 7}, cb.GetAll(), "should be a copy")
+	assert.Equal(t, 4, cb.Size(), "should not remove elements")
+}
+
+func TestCircularBuffer_All(t *testing.T) {
+	cb := NewCircularBuffer[int](3)
+	for range cb.All() {
+		t.Fatal("should yield nothing on empty buffer")
+	}
+
+	for i := 1; i <= 5; i++ {
+		cb.Append(i)
+		var idx, got []int
+		for i, v := range cb.All() {
+			idx = append(idx, i)
+			got = append(got, v)
+		}
+		assert.Equal(t, cb.GetAll(), got, "after appending %d", i)
+		for j, v := range idx {
+			assert.Equal(t, j, v)
+		}
+	}
+
+	// early break
+	var got []int
+	for _, v := range cb.All() {
+		if v == 4 {
+			break
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{3}, got)
+
+	all := cb.GetAll()
+	all[0] = 100
+	assert.Equal(t, []int{3, 4, 5}, cb.GetAll(), "GetAll should return a copy")
+	assert.Zero(t, testing.AllocsPerRun(10, func() {
+		for range cb.All() {
+		}
+	}))
+}
+
+func TestCircularBuffer_AppendAll(t *testing.T) {
+	// compare with Append in a loop, starting from every position and size
+	for size := 0; size <= 4; size++ {
+		for shift := 0; shift <= 4; shift++ {
+			for n := 0; n <= 10; n++ {
+				want := NewCircularBuffer[int](4)
+				got := NewCircularBuffer[int](4)
+				for range shift {
+					want.Append(-1)
+					got.Append(-1)
+					want.PopFront()
+					got.PopFront()
+				}
+				for i := range size {
+					want.Append(i)
+					got.Append(i)
+				}
+
+				items := make([]int, n)
+				for i := range items {
+					items[i] = 100 + i
+					want.Append(items[i])
+				}
+				got.AppendAll(items...)
+				assert.Equal(t, want.GetAll(), got.GetAll(), "size %d, shift %d, n %d", size, shift, n)
+				assert.Equal(t, want.Size(), got.Size(), "size %d, shift %d, n %d", size, shift, n)
+
+				got.Append(1000)
+				want.Append(1000)
+				assert.Equal(t, want.GetAll(), got.GetAll(), "append after batch: size %d, shift %d, n %d", size, shift, n)
+			}
+		}
+	}
+}
+
+func TestCircularBuffer_Clone(t *testing.T) {
+	cb := NewCircularBuffer[int](3)
+	for i := 1; i <= 4; i++ {
+		cb.Append(i)
+	}
+
+	c := cb.Clone()
+	assert.Equal(t, cb.GetAll(), c.GetAll())
+	assert.Equal(t, cb.Capacity(), c.Capacity())
+
+	c.Append(5)
+	c.PopFront()
+	assert.Equal(t, []int{2, 3, 4}, cb.GetAll(), "original should not change")
+	assert.Equal(t, 3, cb.Size())
+	assert.Equal(t, []int{4, 5}, c.GetAll())
+
+	cb.Prepend(0)
+	assert.Equal(t, []int{4, 5}, c.GetAll(), "clone should not change")
+}
@@ -0,0 +1,24 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Resizable is an optional interface for components. Layouts call OnResize
+// after sending ResizeMsg to the component, so it can recompute things depend
+// on its size without matching ResizeMsg in Update.
+type Resizable interface {
+	OnResize(width, height int)
+}
+
+// Resize sends a ResizeMsg to m, and calls OnResize of the updated model if it
+// implements [Resizable]. Layouts should use it to resize children.
+func Resize(m tea.Model, width, height int) (tea.Model, tea.Cmd) {
+	m, cmd := m.Update(ResizeMsg{Width: width, Height: height})
+	if r, ok := m.(Resizable); ok {
+		r.OnResize(width, height)
+	}
+	return m, cmd
+}
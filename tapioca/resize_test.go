@@ -0,0 +1,37 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+type resizeRecorder struct {
+	calls []string
+}
+
+func (r *resizeRecorder) Init() tea.Cmd { return nil }
+func (r *resizeRecorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(ResizeMsg); ok {
+		r.calls = append(r.calls, fmt.Sprintf("msg %dx%d", msg.Width, msg.Height))
+	}
+	return r, nil
+}
+func (r *resizeRecorder) View() string { return "" }
+func (r *resizeRecorder) OnResize(w, h int) {
+	r.calls = append(r.calls, fmt.Sprintf("hook %dx%d", w, h))
+}
+
+func TestResize(t *testing.T) {
+	r := &resizeRecorder{}
+	m, cmd := Resize(r, 3, 4)
+	assert.Same(t, r, m)
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"msg 3x4", "hook 3x4"}, r.calls)
+}
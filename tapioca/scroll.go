@@ -5,6 +5,8 @@
 package tapioca
 
 import (
+	"math"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -24,6 +26,39 @@ type ScrollController interface {
 	ScrollToBegin()
 	ScrollToEnd()
 	ScrollTo(col, row int)
+	// ScrollPageUp and ScrollPageDown scroll by Height()-1 rows, so last row
+	// of previous page is kept for context
+	ScrollPageUp()
+	ScrollPageDown()
+	// ScrollPageLeft and ScrollPageRight scroll by Width()-1 columns
+	ScrollPageLeft()
+	ScrollPageRight()
+	// ScrollBy scrolls relatively, negative values scroll left/up
+	ScrollBy(cols, rows int)
+	// ScrollPercentV and ScrollPercentH return how far the viewport is
+	// scrolled, from 0 (top/left) to 1 (bottom/right). It is 0 if there's
+	// nothing to scroll.
+	ScrollPercentV() float64
+	ScrollPercentH() float64
+	// ScrollToPercentV and ScrollToPercentH jump to p of the scrollable
+	// range, p is clamped to [0, 1].
+	ScrollToPercentV(p float64)
+	ScrollToPercentH(p float64)
+	// ExtentH returns total width of the content
+	ExtentH() int
+	// ExtentV returns total height of the content
+	ExtentV() int
+	Position() ScrollPos
+}
+
+// ScrollPos bundles current scroll state of a ScrollController.
+type ScrollPos struct {
+	// offset of the viewport
+	X, Y int
+	// size of the viewport
+	Width, Height int
+	// size of the content
+	ExtentH, ExtentV int
 }
 
 // Scrollable provides a basic implementation of the ScrollController interface.
@@ -41,10 +76,23 @@ func NewScrollable(maxW, maxH func() int) Scrollable {
 	}
 }
 
-func (s *Scrollable) X() int      { return s.x }
-func (s *Scrollable) Y() int      { return s.y }
-func (s *Scrollable) Width() int  { return s.w }
-func (s *Scrollable) Height() int { return s.h }
+func (s *Scrollable) X() int       { return s.x }
+func (s *Scrollable) Y() int       { return s.y }
+func (s *Scrollable) Width() int   { return s.w }
+func (s *Scrollable) Height() int  { return s.h }
+func (s *Scrollable) ExtentH() int { return s.maxW() }
+func (s *Scrollable) ExtentV() int { return s.maxH() }
+
+func (s *Scrollable) Position() ScrollPos {
+	return ScrollPos{
+		X:       s.x,
+		Y:       s.y,
+		Width:   s.w,
+		Height:  s.h,
+		ExtentH: s.maxW(),
+		ExtentV: s.maxH(),
+	}
+}
 
 func (s *Scrollable) ScrollUp(lines int) {
 	s.y = max(0, s.y-max(lines, 0))
@@ -77,13 +125,74 @@ func (s *Scrollable) ScrollTo(col, row int) {
 	s.ScrollDown(row)
 }
 
+func (s *Scrollable) ScrollPageUp() {
+	s.ScrollUp(max(1, s.h-1))
+}
+func (s *Scrollable) ScrollPageDown() {
+	s.ScrollDown(max(1, s.h-1))
+}
+func (s *Scrollable) ScrollPageLeft() {
+	s.ScrollLeft(max(1, s.w-1))
+}
+func (s *Scrollable) ScrollPageRight() {
+	s.ScrollRight(max(1, s.w-1))
+}
+
+func (s *Scrollable) ScrollBy(cols, rows int) {
+	if cols < 0 {
+		s.ScrollLeft(-cols)
+	} else {
+		s.ScrollRight(cols)
+	}
+	if rows < 0 {
+		s.ScrollUp(-rows)
+	} else {
+		s.ScrollDown(rows)
+	}
+}
+
+func (s *Scrollable) ScrollPercentV() float64 {
+	return scrollPercent(s.y, s.maxH()-s.h)
+}
+func (s *Scrollable) ScrollPercentH() float64 {
+	return scrollPercent(s.x, s.maxW()-s.w)
+}
+func (s *Scrollable) ScrollToPercentV(p float64) {
+	s.y = scrollOffset(p, s.maxH()-s.h)
+}
+func (s *Scrollable) ScrollToPercentH(p float64) {
+	s.x = scrollOffset(p, s.maxW()-s.w)
+}
+
+// scrollPercent returns offset/rng clamped to [0, 1]
+func scrollPercent(offset, rng int) float64 {
+	if rng <= 0 {
+		return 0
+	}
+	return min(1, max(0, float64(offset)/float64(rng)))
+}
+
+// scrollOffset converts p of rng to an offset
+func scrollOffset(p float64, rng int) int {
+	if rng <= 0 {
+		return 0
+	}
+	return int(math.Round(min(1, max(0, p)) * float64(rng)))
+}
+
+// Clamp moves the viewport back into bounds of the content. Components should
+// call it after the content shrinks, like entries being cleared or evicted, so
+// the viewport does not point past the end until next ResizeMsg.
+func (s *Scrollable) Clamp() {
+	s.x = min(max(0, s.maxW()-s.w), s.x)
+	s.y = min(max(0, s.maxH()-s.h), s.y)
+}
+
 func (s *Scrollable) HandleEvent(msg tea.Msg) {
 	switch m := msg.(type) {
 	case ResizeMsg:
 		s.w, s.h = m.Width, m.Height
-		// recompute x, y to be in bounds
-		s.x = min(max(0, s.maxW()-s.w), s.x)
-		s.y = min(max(0, s.maxH()-s.h), s.y)
+		s.Clamp()
 	case ScrollBeginMsg:
 		s.ScrollToBegin()
 	case ScrollEndMsg:
@@ -100,7 +209,13 @@ func (s *Scrollable) HandleEvent(msg tea.Msg) {
 		s.ScrollUp(int(m))
 	case ScrollDownMsg:
 		s.ScrollDown(int(m))
+	case ScrollPageUpMsg:
+		s.ScrollPageUp()
+	case ScrollPageDownMsg:
+		s.ScrollPageDown()
 	case ScrollToMsg:
 		s.ScrollTo(m.X, m.Y)
+	case ScrollByMsg:
+		s.ScrollBy(m.X, m.Y)
 	}
 }
@@ -0,0 +1,155 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScrollable(maxW, maxH int) *Scrollable {
+	s := NewScrollable(func() int { return maxW }, func() int { return maxH })
+	return &s
+}
+
+func TestScrollable_Position(t *testing.T) {
+	s := newTestScrollable(30, 20)
+	s.HandleEvent(ResizeMsg{Width: 10, Height: 5})
+	s.HandleEvent(ScrollToMsg{X: 3, Y: 4})
+
+	assert.Equal(t, 30, s.ExtentH())
+	assert.Equal(t, 20, s.ExtentV())
+	assert.Equal(t, ScrollPos{
+		X: 3, Y: 4,
+		Width: 10, Height: 5,
+		ExtentH: 30, ExtentV: 20,
+	}, s.Position())
+}
+
+func TestScrollable_ScrollBy(t *testing.T) {
+	s := newTestScrollable(30, 20)
+	s.HandleEvent(ResizeMsg{Width: 10, Height: 5})
+
+	s.ScrollBy(3, 4)
+	assert.Equal(t, 3, s.X())
+	assert.Equal(t, 4, s.Y())
+
+	s.ScrollBy(-1, 2)
+	assert.Equal(t, 2, s.X())
+	assert.Equal(t, 6, s.Y())
+
+	// clamped on both axes
+	s.ScrollBy(100, -100)
+	assert.Equal(t, 20, s.X())
+	assert.Equal(t, 0, s.Y())
+
+	s.HandleEvent(ScrollByMsg{X: -100, Y: 100})
+	assert.Equal(t, 0, s.X())
+	assert.Equal(t, 15, s.Y())
+}
+
+func TestScrollable_Clamp(t *testing.T) {
+	maxW, maxH := 30, 20
+	s := NewScrollable(func() int { return maxW }, func() int { return maxH })
+	s.HandleEvent(ResizeMsg{Width: 10, Height: 5})
+	s.ScrollToEnd()
+	s.ScrollToBottom()
+	assert.Equal(t, 20, s.X())
+	assert.Equal(t, 15, s.Y())
+
+	// content shrinks
+	maxW, maxH = 12, 7
+	s.Clamp()
+	assert.Equal(t, 2, s.X())
+	assert.Equal(t, 2, s.Y())
+
+	// smaller than viewport
+	maxW, maxH = 3, 0
+	s.Clamp()
+	assert.Equal(t, 0, s.X())
+	assert.Equal(t, 0, s.Y())
+
+	// growing does not move the viewport
+	maxW, maxH = 100, 100
+	s.ScrollTo(4, 5)
+	s.Clamp()
+	assert.Equal(t, 4, s.X())
+	assert.Equal(t, 5, s.Y())
+}
+
+func TestScrollable_Page(t *testing.T) {
+	s := newTestScrollable(30, 20)
+	s.HandleEvent(ResizeMsg{Width: 10, Height: 5})
+
+	s.HandleEvent(ScrollPageDownMsg{})
+	assert.Equal(t, 4, s.Y())
+	s.ScrollPageDown()
+	s.ScrollPageDown()
+	assert.Equal(t, 12, s.Y())
+	s.ScrollPageDown()
+	assert.Equal(t, 15, s.Y(), "should be clamped at bottom")
+	s.HandleEvent(ScrollPageUpMsg{})
+	assert.Equal(t, 11, s.Y())
+	s.ScrollPageUp()
+	s.ScrollPageUp()
+	s.ScrollPageUp()
+	assert.Equal(t, 0, s.Y(), "should be clamped at top")
+
+	s.ScrollPageRight()
+	assert.Equal(t, 9, s.X())
+	s.ScrollPageRight()
+	s.ScrollPageRight()
+	assert.Equal(t, 20, s.X(), "should be clamped at end")
+	s.ScrollPageLeft()
+	assert.Equal(t, 11, s.X())
+	s.ScrollPageLeft()
+	s.ScrollPageLeft()
+	assert.Equal(t, 0, s.X(), "should be clamped at begin")
+
+	// at least 1 row
+	s.HandleEvent(ResizeMsg{Width: 1, Height: 1})
+	s.ScrollPageDown()
+	assert.Equal(t, 1, s.Y())
+	s.ScrollPageRight()
+	assert.Equal(t, 1, s.X())
+}
+
+func TestScrollable_Percent(t *testing.T) {
+	maxW, maxH := 30, 25
+	s := NewScrollable(func() int { return maxW }, func() int { return maxH })
+	s.HandleEvent(ResizeMsg{Width: 10, Height: 5})
+	assert.Equal(t, 0.0, s.ScrollPercentV())
+	assert.Equal(t, 0.0, s.ScrollPercentH())
+
+	s.ScrollTo(5, 10)
+	assert.Equal(t, 0.5, s.ScrollPercentV())
+	assert.Equal(t, 0.25, s.ScrollPercentH())
+
+	s.ScrollToBottom()
+	s.ScrollToEnd()
+	assert.Equal(t, 1.0, s.ScrollPercentV())
+	assert.Equal(t, 1.0, s.ScrollPercentH())
+
+	s.ScrollToPercentV(0.25)
+	s.ScrollToPercentH(0.5)
+	assert.Equal(t, 5, s.Y())
+	assert.Equal(t, 10, s.X())
+
+	// p is clamped
+	s.ScrollToPercentV(2)
+	assert.Equal(t, 20, s.Y())
+	s.ScrollToPercentV(-1)
+	assert.Equal(t, 0, s.Y())
+
+	// nothing to scroll
+	maxW, maxH = 8, 3
+	s.ScrollToPercentV(0.5)
+	s.ScrollToPercentH(0.5)
+	assert.Equal(t, 0, s.Y())
+	assert.Equal(t, 0, s.X())
+	assert.Equal(t, 0.0, s.ScrollPercentV())
+	assert.Equal(t, 0.0, s.ScrollPercentH())
+}
@@ -6,6 +6,7 @@ package tapioca
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -19,6 +20,12 @@ type style struct {
 	blink     bool
 	reverse   bool
 	hidden    bool
+
+	// underline style with sub parameter like "4:3" (curly), empty for
+	// single underline
+	ulStyle string
+	// underline color like "58;5;n"
+	ulColor string
 }
 
 func (s *style) Clone() *style {
@@ -34,23 +41,92 @@ func (s *style) isEmpty() bool {
 	if s == nil {
 		return true
 	}
-	return s.fg == "" && s.bg == "" && !s.bold && !s.faint && !s.italic && !s.underline && !s.strike && !s.blink && !s.reverse && !s.hidden
+	return s.fg == "" && s.bg == "" && !s.bold && !s.faint && !s.italic && !s.underline && s.ulColor == "" && !s.strike && !s.blink && !s.reverse && !s.hidden
 }
 
 // Render returns the ANSI escape sequence to transition from prevStyle to s.
 //
-// It uses a really simple strategy: if prevStyle is not empty, it resets
-// all styles first, then applies s. This is not the most efficient way,
-// but it's simple and works well enough in practice.
+// Only changed attributes are emitted, using their off codes (like "22" or
+// "39") to clear attributes. It falls back to reset and full style if that is
+// shorter.
 func (s *style) Render(prevStyle *style) string {
-	if !prevStyle.isEmpty() {
-		return "\x1b[0m" + s.String()
+	if prevStyle.isEmpty() {
+		return s.String()
+	}
+	if s.isEmpty() {
+		return "\x1b[0m"
+	}
+
+	b := &strings.Builder{}
+	w := func(param string) {
+		b.WriteString("\x1b[")
+		b.WriteString(param)
+		b.WriteString("m")
+	}
+	// onOff emits param if enabled, or off if disabled, when it is changed
+	onOff := func(prev, cur bool, on, off string) {
+		switch {
+		case cur && !prev:
+			w(on)
+		case prev && !cur:
+			w(off)
+		}
 	}
+	// color emits cur if changed, or off if cleared
+	color := func(prev, cur, kind, off string) {
+		prev, cur = degradeColor(prev, kind, Colors), degradeColor(cur, kind, Colors)
+		switch {
+		case prev == cur:
+		case cur == "":
+			w(off)
+		default:
+			w(cur)
+		}
+	}
+
+	color(prevStyle.fg, s.fg, "38", "39")
+	color(prevStyle.bg, s.bg, "48", "49")
+	// 22 turns off both bold and faint
+	if (prevStyle.bold && !s.bold) || (prevStyle.faint && !s.faint) {
+		w("22")
+		onOff(false, s.bold, "1", "")
+		onOff(false, s.faint, "2", "")
+	} else {
+		onOff(prevStyle.bold, s.bold, "1", "")
+		onOff(prevStyle.faint, s.faint, "2", "")
+	}
+	onOff(prevStyle.italic, s.italic, "3", "23")
+	if prev, cur := prevStyle.underlineParam(), s.underlineParam(); prev != cur {
+		if cur == "" {
+			cur = "24"
+		}
+		w(cur)
+	}
+	onOff(prevStyle.blink, s.blink, "5", "25")
+	onOff(prevStyle.reverse, s.reverse, "7", "27")
+	onOff(prevStyle.hidden, s.hidden, "8", "28")
+	onOff(prevStyle.strike, s.strike, "9", "29")
+	color(prevStyle.ulColor, s.ulColor, "58", "59")
 
-	return s.String()
+	if full := "\x1b[0m" + s.String(); len(full) <= b.Len() {
+		return full
+	}
+	return b.String()
+}
+
+// underlineParam returns SGR parameter of underline, empty if not underlined
+func (s *style) underlineParam() string {
+	if s.ulStyle != "" {
+		return s.ulStyle
+	}
+	if s.underline {
+		return "4"
+	}
+	return ""
 }
 
-// String renders the style as an ANSI escape sequence.
+// String renders the style as an ANSI escape sequence. Colors are converted if
+// the terminal does not support them, see [Colors].
 func (s *style) String() string {
 	if s.isEmpty() {
 		return ""
@@ -74,25 +150,51 @@ func (s *style) String() string {
 		}
 	}
 
-	str(s.fg)
-	str(s.bg)
+	str(degradeColor(s.fg, "38", Colors))
+	str(degradeColor(s.bg, "48", Colors))
 	bool(s.bold, "1")
 	bool(s.faint, "2")
 	bool(s.italic, "3")
-	bool(s.underline, "4")
+	str(s.underlineParam())
 	bool(s.blink, "5")
 	bool(s.reverse, "7")
 	bool(s.hidden, "8")
 	bool(s.strike, "9")
+	str(degradeColor(s.ulColor, "58", Colors))
 
 	return b.String()
 }
 
 var (
-	ansiStyleRegex = regexp.MustCompile(`\x1b\[([0-9]{1,3}(;[0-9]{1,3})*)?m`)
-	ansiOtherRegex = regexp.MustCompile(`\x1b\[([0-9]+(;[0-9]+)*)?[ABCDEFGHJKSTfsuhl]`)
+	ansiStyleRegex = regexp.MustCompile(`\x1b\[([0-9]{1,3}([;:][0-9]{0,3})*)?m`)
+	// escape sequences other than SGR: any CSI sequence, OSC sequences and
+	// DCS/SOS/PM/APC strings terminated by BEL or ST, and two-byte ESC
+	// sequences like "\x1b7" or "\x1b(B". SGR and OSC are kept by
+	// dropOtherANSI since they are parsed later.
+	ansiOtherRegex = regexp.MustCompile(
+		`\x1b\[[0-?]*[ -/]*[@-~]` +
+			`|\x1b[\]PX^_][^\x07\x1b]*(\x07|\x1b\\)` +
+			"|\x1b[ -/]*[0-OQ-WYZ\\\\`-~]",
+	)
 )
 
+// dropOtherANSI removes escape sequences matched by ansiOtherRegex, except SGR
+// and OSC
+func dropOtherANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiOtherRegex.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasPrefix(m, "\x1b]") {
+			return m
+		}
+		if loc := ansiStyleRegex.FindStringIndex(m); loc != nil && loc[0] == 0 && loc[1] == len(m) {
+			return m
+		}
+		return ""
+	})
+}
+
 // parseAnsiCode takes a full escape sequence (e.g., "\x1b[32m") and the previous style.
 // It returns a new style object.
 func parseAnsiCode(code string, previousStyle *style) *style {
@@ -112,6 +214,11 @@ func parseAnsiCode(code string, previousStyle *style) *style {
 	parts := strings.Split(paramsStr, ";")
 	for i := 0; i < len(parts); i++ {
 		part := parts[i]
+		if strings.Contains(part, ":") {
+			// sub parameters, like "4:3" or "58:2::r:g:b"
+			parseSubParams(part, newStyle)
+			continue
+		}
 		switch part {
 		case "0": // Reset
 			if !newStyle.isEmpty() {
@@ -140,6 +247,7 @@ func parseAnsiCode(code string, previousStyle *style) *style {
 			newStyle.italic = false
 		case "24": // No underline
 			newStyle.underline = false
+			newStyle.ulStyle = ""
 		case "25": // No blink
 			newStyle.blink = false
 		case "27": // No reverse
@@ -152,6 +260,8 @@ func parseAnsiCode(code string, previousStyle *style) *style {
 			newStyle.fg = ""
 		case "49": // Default background
 			newStyle.bg = ""
+		case "59": // Default underline color
+			newStyle.ulColor = ""
 		case "30", "31", "32", "33", "34", "35", "36", "37": // Standard foreground colors
 			newStyle.fg = part
 		case "40", "41", "42", "43", "44", "45", "46", "47": // Standard background colors
@@ -161,25 +271,23 @@ func parseAnsiCode(code string, previousStyle *style) *style {
 		case "100", "101", "102", "103", "104", "105", "106", "107": // Bright background colors
 			newStyle.bg = part
 		case "38": // 256-color or RGB foreground
-			if i+2 < len(parts) && parts[i+1] == "5" {
-				// 256-color: 38;5;n
-				newStyle.fg = "38;5;" + parts[i+2]
-				i += 2 // Skip the next two parts
-			} else if i+4 < len(parts) && parts[i+1] == "2" {
-				// RGB: 38;2;r;g;b
-				newStyle.fg = "38;2;" + parts[i+2] + ";" + parts[i+3] + ";" + parts[i+4]
-				i += 4 // Skip the next four parts
+			color, n := parseExtColor(parts[i:])
+			if color != "" {
+				newStyle.fg = color
 			}
+			i += n
 		case "48": // 256-color or RGB background
-			if i+2 < len(parts) && parts[i+1] == "5" {
-				// 256-color: 48;5;n
-				newStyle.bg = "48;5;" + parts[i+2]
-				i += 2 // Skip the next two parts
-			} else if i+4 < len(parts) && parts[i+1] == "2" {
-				// RGB: 48;2;r;g;b
-				newStyle.bg = "48;2;" + parts[i+2] + ";" + parts[i+3] + ";" + parts[i+4]
-				i += 4 // Skip the next four parts
+			color, n := parseExtColor(parts[i:])
+			if color != "" {
+				newStyle.bg = color
+			}
+			i += n
+		case "58": // 256-color or RGB underline
+			color, n := parseExtColor(parts[i:])
+			if color != "" {
+				newStyle.ulColor = color
 			}
+			i += n
 			// Ignore unknown codes (error handling strategy: continue with next codes)
 		}
 	}
@@ -188,3 +296,64 @@ func parseAnsiCode(code string, previousStyle *style) *style {
 	}
 	return newStyle
 }
+
+// parseExtColor parses extended color like "38;5;n" or "38;2;r;g;b" at the
+// beginning of parts. n is number of parts consumed after the first one.
+//
+// Malformed color is ignored: color is empty if subtype is not 2 or 5, or
+// values are missing or not in 0-255. Only values belong to the color are
+// consumed, so following params are parsed as usual.
+func parseExtColor(parts []string) (color string, n int) {
+	if len(parts) < 2 {
+		return "", 0
+	}
+
+	var want int
+	switch parts[1] {
+	case "5":
+		want = 1
+	case "2":
+		want = 3
+	default:
+		return "", 0
+	}
+
+	n = min(1+want, len(parts)-1)
+	if n < 1+want {
+		return "", n
+	}
+	for _, v := range parts[2 : 2+want] {
+		if x, err := strconv.Atoi(v); err != nil || x < 0 || x > 255 {
+			return "", n
+		}
+	}
+	return strings.Join(parts[:2+want], ";"), n
+}
+
+// parseSubParams handles a colon separated parameter, the sub parameters are
+// kept as is
+func parseSubParams(part string, s *style) {
+	head, sub, _ := strings.Cut(part, ":")
+	switch head {
+	case "4":
+		// 4:0 is no underline, 4:1 is single underline, others are styles
+		// like curly (4:3)
+		switch sub {
+		case "0":
+			s.underline = false
+			s.ulStyle = ""
+		case "1":
+			s.underline = true
+			s.ulStyle = ""
+		default:
+			s.underline = true
+			s.ulStyle = part
+		}
+	case "38":
+		s.fg = part
+	case "48":
+		s.bg = part
+	case "58":
+		s.ulColor = part
+	}
+}
@@ -0,0 +1,40 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+// Merge returns a new style with over applied on s: colors and underline style
+// of over replace those of s if set, boolean attributes are OR'ed together.
+// It returns nil if the result is empty.
+func (s *style) Merge(over *style) *style {
+	if over.isEmpty() {
+		return s.Clone()
+	}
+	ret := s.Clone()
+	if ret == nil {
+		ret = &style{}
+	}
+
+	if over.fg != "" {
+		ret.fg = over.fg
+	}
+	if over.bg != "" {
+		ret.bg = over.bg
+	}
+	if over.ulStyle != "" {
+		ret.ulStyle = over.ulStyle
+	}
+	if over.ulColor != "" {
+		ret.ulColor = over.ulColor
+	}
+	ret.bold = ret.bold || over.bold
+	ret.faint = ret.faint || over.faint
+	ret.italic = ret.italic || over.italic
+	ret.underline = ret.underline || over.underline
+	ret.strike = ret.strike || over.strike
+	ret.blink = ret.blink || over.blink
+	ret.reverse = ret.reverse || over.reverse
+	ret.hidden = ret.hidden || over.hidden
+	return ret
+}
@@ -0,0 +1,70 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyle_Merge(t *testing.T) {
+	cases := []struct {
+		name       string
+		base, over *style
+		expected   *style
+	}{
+		{
+			name:     "nil+nil",
+			expected: nil,
+		},
+		{
+			name:     "nil base",
+			over:     &style{fg: "31"},
+			expected: &style{fg: "31"},
+		},
+		{
+			name:     "nil over",
+			base:     &style{fg: "31", bold: true},
+			expected: &style{fg: "31", bold: true},
+		},
+		{
+			name:     "fg override",
+			base:     &style{fg: "31", bg: "44"},
+			over:     &style{fg: "38;5;208"},
+			expected: &style{fg: "38;5;208", bg: "44"},
+		},
+		{
+			name:     "bg override",
+			base:     &style{fg: "31", bg: "44"},
+			over:     &style{bg: "48;2;1;2;3"},
+			expected: &style{fg: "31", bg: "48;2;1;2;3"},
+		},
+		{
+			name:     "bold and underline accumulate",
+			base:     &style{bold: true},
+			over:     &style{underline: true, ulStyle: "4:3", ulColor: "58;5;1"},
+			expected: &style{bold: true, underline: true, ulStyle: "4:3", ulColor: "58;5;1"},
+		},
+		{
+			name:     "attributes are not turned off",
+			base:     &style{bold: true, italic: true, strike: true},
+			over:     &style{reverse: true},
+			expected: &style{bold: true, italic: true, strike: true, reverse: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var before *style
+			if tc.base != nil {
+				c := *tc.base
+				before = &c
+			}
+			assert.Equal(t, tc.expected, tc.base.Merge(tc.over))
+			assert.Equal(t, before, tc.base, "base is not modified")
+		})
+	}
+}
@@ -88,3 +88,141 @@ func TestStyle_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestStyle_ParseUnderline(t *testing.T) {
+	cases := []struct {
+		name     string
+		code     string
+		prev     *style
+		expected *style
+	}{
+		{
+			name:     "curly underline",
+			code:     "\x1b[4:3m",
+			expected: &style{underline: true, ulStyle: "4:3"},
+		},
+		{
+			name:     "single underline with colon",
+			code:     "\x1b[4:1m",
+			prev:     &style{underline: true, ulStyle: "4:3"},
+			expected: &style{underline: true},
+		},
+		{
+			name:     "no underline with colon",
+			code:     "\x1b[4:0m",
+			prev:     &style{underline: true, ulStyle: "4:3", fg: "31"},
+			expected: &style{fg: "31"},
+		},
+		{
+			name:     "256 underline color",
+			code:     "\x1b[4:3;58;5;196m",
+			expected: &style{underline: true, ulStyle: "4:3", ulColor: "58;5;196"},
+		},
+		{
+			name:     "RGB underline color",
+			code:     "\x1b[58;2;1;2;3m",
+			expected: &style{ulColor: "58;2;1;2;3"},
+		},
+		{
+			name:     "RGB underline color with colon",
+			code:     "\x1b[58:2::1:2:3m",
+			expected: &style{ulColor: "58:2::1:2:3"},
+		},
+		{
+			name:     "RGB foreground with colon",
+			code:     "\x1b[38:2::1:2:3m",
+			expected: &style{fg: "38:2::1:2:3"},
+		},
+		{
+			name:     "reset underline",
+			code:     "\x1b[24;59m",
+			prev:     &style{underline: true, ulStyle: "4:3", ulColor: "58;5;1", bold: true},
+			expected: &style{bold: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseAnsiCode(tc.code, tc.prev)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		e := NewEntry("\x1b[4:3;58:2::255:0:0merr\x1b[0m ok")
+		assert.Equal(t, "err ok", e.String())
+		assert.Equal(t, "\x1b[4:3m\x1b[58:2::255:0:0merr\x1b[0m ok", e.StyledString())
+	})
+}
+
+func TestStyle_ParseExtColor(t *testing.T) {
+	cases := []struct {
+		name     string
+		code     string
+		prev     *style
+		expected *style
+	}{
+		{
+			name:     "bare 38",
+			code:     "\x1b[38m",
+			prev:     &style{fg: "31"},
+			expected: &style{fg: "31"},
+		},
+		{
+			name:     "bare 48 followed by other params",
+			code:     "\x1b[48;1m",
+			expected: &style{bold: true},
+		},
+		{
+			name:     "unknown subtype",
+			code:     "\x1b[38;3;1m",
+			expected: &style{italic: true, bold: true},
+		},
+		{
+			name:     "missing 256 color index",
+			code:     "\x1b[38;5m",
+			prev:     &style{fg: "31"},
+			expected: &style{fg: "31"},
+		},
+		{
+			name:     "truncated RGB",
+			code:     "\x1b[38;2;1;2m",
+			expected: nil,
+		},
+		{
+			name:     "RGB out of range",
+			code:     "\x1b[48;2;1;256;3;1m",
+			prev:     &style{bg: "41"},
+			expected: &style{bg: "41", bold: true},
+		},
+		{
+			name:     "empty 256 color index",
+			code:     "\x1b[38;5;;4m",
+			expected: &style{underline: true},
+		},
+		{
+			name:     "256 color followed by other params",
+			code:     "\x1b[38;5;208;1m",
+			expected: &style{fg: "38;5;208", bold: true},
+		},
+		{
+			name:     "default foreground and background",
+			code:     "\x1b[39;49m",
+			prev:     &style{fg: "38;5;208", bg: "48;2;1;2;3", bold: true},
+			expected: &style{bold: true},
+		},
+		{
+			name:     "default foreground and background only",
+			code:     "\x1b[39;49m",
+			prev:     &style{fg: "31", bg: "44"},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseAnsiCode(tc.code, tc.prev)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
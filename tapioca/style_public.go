@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+// Style is a set of text attributes, so you can style text without writing
+// escape sequences. Zero value is the default style.
+//
+// Each attribute maps to an SGR parameter:
+//
+//	Bold       1
+//	Faint      2
+//	Italic     3
+//	Underline  4 (or UnderlineStyle if set)
+//	Blink      5
+//	Reverse    7
+//	Hidden     8
+//	Strike     9
+type Style struct {
+	// Fg is SGR parameter of foreground color: "30"-"37" and "90"-"97" for
+	// basic colors, "38;5;n" for 256 colors, or "38;2;r;g;b" for RGB colors.
+	// Empty for default color.
+	Fg string
+	// Bg is SGR parameter of background color: "40"-"47" and "100"-"107" for
+	// basic colors, "48;5;n" for 256 colors, or "48;2;r;g;b" for RGB colors.
+	// Empty for default color.
+	Bg string
+
+	Bold      bool
+	Faint     bool
+	Italic    bool
+	Underline bool
+	Blink     bool
+	Reverse   bool
+	Hidden    bool
+	Strike    bool
+
+	// UnderlineStyle is SGR parameter like "4:3" (curly underline), it
+	// implies Underline. Empty for single underline.
+	UnderlineStyle string
+	// UnderlineColor is SGR parameter like "58;5;n" or "58;2;r;g;b". Empty
+	// for default color.
+	UnderlineColor string
+}
+
+// toStyle converts s to internal style, nil if it is empty
+func (s Style) toStyle() *style {
+	ret := &style{
+		fg:        s.Fg,
+		bg:        s.Bg,
+		bold:      s.Bold,
+		faint:     s.Faint,
+		italic:    s.Italic,
+		underline: s.Underline || s.UnderlineStyle != "",
+		strike:    s.Strike,
+		blink:     s.Blink,
+		reverse:   s.Reverse,
+		hidden:    s.Hidden,
+		ulStyle:   s.UnderlineStyle,
+		ulColor:   s.UnderlineColor,
+	}
+	if ret.isEmpty() {
+		return nil
+	}
+	return ret
+}
+
+// fromStyle converts internal style to Style
+func fromStyle(s *style) Style {
+	if s == nil {
+		return Style{}
+	}
+	return Style{
+		Fg:             s.fg,
+		Bg:             s.bg,
+		Bold:           s.bold,
+		Faint:          s.faint,
+		Italic:         s.italic,
+		Underline:      s.underline,
+		Blink:          s.blink,
+		Reverse:        s.reverse,
+		Hidden:         s.hidden,
+		Strike:         s.strike,
+		UnderlineStyle: s.ulStyle,
+		UnderlineColor: s.ulColor,
+	}
+}
+
+// ParseStyle parses SGR sequences like "\x1b[1;31m" into a Style. Multiple
+// sequences are applied in order, other content is ignored.
+func ParseStyle(sgr string) Style {
+	var ret *style
+	for _, code := range ansiStyleRegex.FindAllString(sgr, -1) {
+		ret = parseAnsiCode(code, ret)
+	}
+	return fromStyle(ret)
+}
+
+// Merge returns a new Style with over applied on s. Colors of over replace
+// those of s if set, and boolean attributes like bold are accumulated.
+func (s Style) Merge(over Style) Style {
+	return fromStyle(s.toStyle().Merge(over.toStyle()))
+}
+
+// IsZero reports whether s is the default style.
+func (s Style) IsZero() bool {
+	return s.toStyle() == nil
+}
+
+// String returns SGR sequences to apply the style, empty for the default
+// style.
+func (s Style) String() string {
+	return s.toStyle().String()
+}
+
+// NewStyledEntry creates an Entry of text with style s. Text is not scanned for
+// escape sequences, like [NewPlainEntry].
+func NewStyledEntry(text string, s Style) *Entry {
+	st := s.toStyle()
+	styledData := make([]StyledRune, 0, len(text))
+	for _, r := range text {
+		styledData = appendCluster(styledData, StyledRune{Rune: r, Style: st})
+	}
+	return newEntry(styledData)
+}
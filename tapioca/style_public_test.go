@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyle_Public(t *testing.T) {
+	base := ParseStyle("\x1b[31m\x1b[1mtext\x1b[44m")
+	assert.Equal(t, Style{Fg: "31", Bg: "44", Bold: true}, base)
+	assert.Equal(t, "\x1b[31m\x1b[44m\x1b[1m", base.String())
+
+	got := base.Merge(ParseStyle("\x1b[32;4m"))
+	assert.Equal(t, Style{Fg: "32", Bg: "44", Bold: true, Underline: true}, got)
+	assert.Equal(t, Style{Fg: "31", Bg: "44", Bold: true}, base, "base is not modified")
+
+	assert.True(t, Style{}.IsZero())
+	assert.True(t, ParseStyle("\x1b[1m\x1b[0m").IsZero())
+	assert.False(t, Style{Hidden: true}.IsZero())
+	assert.Equal(t, "", Style{}.Merge(Style{}).String())
+	assert.Equal(t, "\x1b[3m", Style{}.Merge(Style{Italic: true}).String())
+}
+
+func TestStyle_PublicString(t *testing.T) {
+	cases := []struct {
+		style    Style
+		expected string
+	}{
+		{Style{}, ""},
+		{Style{Fg: "38;5;208"}, "\x1b[38;5;208m"},
+		{Style{Bg: "48;2;1;2;3", Faint: true}, "\x1b[48;2;1;2;3m\x1b[2m"},
+		{
+			Style{Bold: true, Italic: true, Underline: true, Blink: true, Reverse: true, Hidden: true, Strike: true},
+			"\x1b[1m\x1b[3m\x1b[4m\x1b[5m\x1b[7m\x1b[8m\x1b[9m",
+		},
+		{Style{UnderlineStyle: "4:3", UnderlineColor: "58;5;1"}, "\x1b[4:3m\x1b[58;5;1m"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.style.String(), "%+v", c.style)
+		// round trip
+		assert.Equal(t, c.expected, ParseStyle(c.expected).String(), "%+v", c.style)
+	}
+}
+
+func TestNewStyledEntry(t *testing.T) {
+	e := NewStyledEntry("ok 中", Style{Fg: "32", Bold: true})
+	assert.Equal(t, "ok 中", e.String())
+	assert.Equal(t, "\x1b[32m\x1b[1mok 中\x1b[0m", e.StyledString())
+	assert.Equal(t, 5, e.Width())
+
+	e = NewStyledEntry("plain", Style{})
+	assert.Equal(t, "plain", e.StyledString())
+}
@@ -68,7 +68,7 @@ func TestStyle_Render(t *testing.T) {
 			name:     "bold+bold",
 			prev:     &style{bold: true},
 			cur:      &style{bold: true},
-			expected: "\x1b[0m\x1b[1m",
+			expected: "",
 		},
 		{
 			name:     "bold+italic",
@@ -76,6 +76,48 @@ func TestStyle_Render(t *testing.T) {
 			cur:      &style{italic: true},
 			expected: "\x1b[0m\x1b[3m",
 		},
+		{
+			name:     "change color",
+			prev:     &style{bold: true, fg: "31"},
+			cur:      &style{bold: true, fg: "38;5;208"},
+			expected: "\x1b[38;5;208m",
+		},
+		{
+			name:     "clear colors",
+			prev:     &style{bold: true, italic: true, fg: "31", bg: "44"},
+			cur:      &style{bold: true, italic: true},
+			expected: "\x1b[39m\x1b[49m",
+		},
+		{
+			name:     "bold to faint",
+			prev:     &style{bold: true, fg: "31"},
+			cur:      &style{faint: true, fg: "31"},
+			expected: "\x1b[22m\x1b[2m",
+		},
+		{
+			name:     "attributes off",
+			prev:     &style{fg: "38;5;208", bold: true, italic: true, blink: true},
+			cur:      &style{fg: "38;5;208", bold: true},
+			expected: "\x1b[23m\x1b[25m",
+		},
+		{
+			name:     "underline style",
+			prev:     &style{fg: "31", underline: true},
+			cur:      &style{fg: "31", underline: true, ulStyle: "4:3"},
+			expected: "\x1b[4:3m",
+		},
+		{
+			name:     "underline off",
+			prev:     &style{fg: "31", underline: true, ulStyle: "4:3"},
+			cur:      &style{fg: "31"},
+			expected: "\x1b[24m",
+		},
+		{
+			name:     "reset is shorter",
+			prev:     &style{fg: "31", bg: "44", bold: true, italic: true},
+			cur:      &style{underline: true},
+			expected: "\x1b[0m\x1b[4m",
+		},
 	}
 
 	for _, tc := range cases {
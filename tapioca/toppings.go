@@ -54,3 +54,19 @@ func IsThisTopping(spec ToppingTestSpec) string {
 
 	return "" // all good
 }
+
+// RenderGolden resizes m to width x height and returns the output of View, so
+// it can be compared with a golden file. Styles are kept, see
+// [RenderGoldenPlain] for the plain text form.
+//
+// Commands returned by m are not executed.
+func RenderGolden(m tea.Model, width, height int) string {
+	m, _ = Resize(m, width, height)
+	return m.View()
+}
+
+// RenderGoldenPlain is like RenderGolden, but escape sequences are removed,
+// which is easier to review.
+func RenderGoldenPlain(m tea.Model, width, height int) string {
+	return StripANSI(RenderGolden(m, width, height))
+}
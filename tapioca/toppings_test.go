@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tapioca
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// redBox fills its area with red "x"
+type redBox struct {
+	w, h int
+}
+
+func (b *redBox) Init() tea.Cmd { return nil }
+func (b *redBox) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(ResizeMsg); ok {
+		b.w, b.h = msg.Width, msg.Height
+	}
+	return b, nil
+}
+func (b *redBox) View() string {
+	lines := make([]string, b.h)
+	for i := range lines {
+		lines[i] = "\x1b[31m" + strings.Repeat("x", b.w) + "\x1b[0m"
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRenderGolden(t *testing.T) {
+	b := &redBox{}
+	assert.Equal(t, "\x1b[31mxxx\x1b[0m\n\x1b[31mxxx\x1b[0m", RenderGolden(b, 3, 2))
+	assert.Equal(t, "xx\nxx\nxx", RenderGoldenPlain(b, 2, 3))
+	assert.Equal(t, 2, b.w, "should be resized")
+	assert.Equal(t, 3, b.h, "should be resized")
+}
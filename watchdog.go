@@ -0,0 +1,115 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package huninn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+)
+
+// WithWatchdog wraps factory for [LSNI] and [LSLI], so the status bar shows
+// "No activity for Ns…" if the job neither writes logs nor sets status for
+// idle, which means the job might be stuck. Status set by the job is restored
+// once it writes logs again.
+//
+// The job is checked periodically, at most once a second, until it returns.
+// The clock is used to track activity, nil means [tapioca.SystemClock]. The
+// factory is returned as is if idle <= 0.
+func WithWatchdog(factory JobFactory, idle time.Duration, clock tapioca.Clock) JobFactory {
+	if idle <= 0 {
+		return factory
+	}
+	if clock == nil {
+		clock = tapioca.SystemClock
+	}
+
+	return func(setStatus func(string), tm pearl.TaskManager, w io.Writer, s tapioca.ScrollController, quit func()) func(context.Context) error {
+		d := &watchdog{
+			idle:      idle,
+			clock:     clock,
+			setStatus: setStatus,
+			w:         w,
+		}
+		job := factory(d.SetStatus, tm, d, s, quit)
+
+		return func(ctx context.Context) error {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			d.lock.Lock()
+			d.last = clock.Now()
+			d.lock.Unlock()
+			go d.run(ctx)
+			return job(ctx)
+		}
+	}
+}
+
+// watchdog tracks activity of a job through its setStatus and writer
+type watchdog struct {
+	idle      time.Duration
+	clock     tapioca.Clock
+	setStatus func(string)
+	w         io.Writer
+
+	lock    sync.Mutex
+	last    time.Time // last activity
+	status  string    // last status set by the job
+	stalled bool      // true if status bar shows the warning
+}
+
+// SetStatus records activity and updates the status bar.
+func (d *watchdog) SetStatus(s string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.last = d.clock.Now()
+	d.status = s
+	d.stalled = false
+	d.setStatus(s)
+}
+
+// Write records activity and writes p to the log panel.
+func (d *watchdog) Write(p []byte) (int, error) {
+	d.lock.Lock()
+	d.last = d.clock.Now()
+	if d.stalled {
+		d.stalled = false
+		d.setStatus(d.status)
+	}
+	d.lock.Unlock()
+	return d.w.Write(p)
+}
+
+// check shows the warning if there's no activity for d.idle
+func (d *watchdog) check() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	quiet := d.clock.Now().Sub(d.last)
+	if quiet < d.idle {
+		return
+	}
+	d.stalled = true
+	d.setStatus(fmt.Sprintf("No activity for %ds…", int(quiet/time.Second)))
+}
+
+// run checks periodically until ctx is done
+func (d *watchdog) run(ctx context.Context) {
+	t := time.NewTicker(min(time.Second, d.idle))
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			d.check()
+		}
+	}
+}
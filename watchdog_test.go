@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package huninn
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/raohwork/huninn/pearl"
+	"github.com/raohwork/huninn/tapioca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchdog(t *testing.T) {
+	clock := tapioca.NewFakeClock(time.Unix(0, 0))
+	var status []string
+	d := &watchdog{
+		idle:      10 * time.Second,
+		clock:     clock,
+		setStatus: func(s string) { status = append(status, s) },
+		w:         &bytes.Buffer{},
+	}
+
+	d.SetStatus("working")
+	clock.Advance(9 * time.Second)
+	d.check()
+	assert.Equal(t, []string{"working"}, status)
+
+	clock.Advance(3 * time.Second)
+	d.check()
+	assert.Equal(t, []string{"working", "No activity for 12s…"}, status)
+
+	// writing logs restores the status
+	d.Write([]byte("hello\n"))
+	assert.Equal(t, "working", status[len(status)-1])
+	assert.Equal(t, "hello\n", d.w.(*bytes.Buffer).String())
+	d.check()
+	assert.Len(t, status, 3)
+
+	// restored only once
+	d.Write([]byte("world\n"))
+	assert.Len(t, status, 3)
+}
+
+func TestWithWatchdog(t *testing.T) {
+	var factory JobFactory = func(setStatus func(string), tm pearl.TaskManager, w io.Writer, s tapioca.ScrollController, quit func()) func(context.Context) error {
+		return func(context.Context) error {
+			setStatus("done")
+			return nil
+		}
+	}
+
+	var status []string
+	wrapped := WithWatchdog(factory, time.Minute, nil)
+	job := wrapped(func(s string) { status = append(status, s) }, nil, &bytes.Buffer{}, nil, func() {})
+	assert.NoError(t, job(context.Background()))
+	assert.Equal(t, []string{"done"}, status)
+}